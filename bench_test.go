@@ -4,6 +4,7 @@ package polynomials
 
 import (
 	// "fmt"
+	"math"
 	"testing"
 )
 
@@ -14,7 +15,7 @@ func BenchmarkDurandKerner(t *testing.B){
 
 		poly.SolveMode = DurandKerner
 		// start := time.Now()
-		_, err := poly.RealRoots()
+		_, err := poly.ComplexRootsDurandKerner()
 		if err != nil {
 			t.Fatalf(`%v`, err)
 		}
@@ -33,7 +34,7 @@ func BenchmarkEigenvalue(t *testing.B){
 
 		poly.SolveMode = Eigenvalue
 		// start = time.Now()
-		_, err := poly.RealRoots()
+		_, err := poly.ComplexRootsEigenvalue()
 		if err != nil {
 			t.Fatalf(`%v`, err)
 
@@ -50,7 +51,7 @@ func BenchmarkNewton(t *testing.B){
 
 		poly.SolveMode = BisectionNewton
 		// start = time.Now()
-		_, err := poly.RealRoots()
+		_, err := poly.RootsBisectionNewton()
 		if err != nil {
 			t.Fatalf(`%v`, err)
 
@@ -74,7 +75,163 @@ func BenchmarkQuadradic(t *testing.B){
 }
 
 
+func BenchmarkAberth(t *testing.B){
+
+		poly := CreatePolynomial(1.13, -5.0, 12.0, -2.8, 3.213)
+
+
+
+		poly.SolveMode = Aberth
+		_, err := poly.ComplexRootsAberth()
+		if err != nil {
+			t.Fatalf(`%v`, err)
+
+		}
+
+}
+
+
+func BenchmarkJenkinsTraub(t *testing.B){
+
+		poly := CreatePolynomial(1.13, -5.0, 12.0, -2.8, 3.213)
+
+
+
+		poly.SolveMode = JenkinsTraub
+		_, err := poly.ComplexRootsJenkinsTraub()
+		if err != nil {
+			t.Fatalf(`%v`, err)
+
+		}
+
+}
+
+
+func BenchmarkLaguerre(t *testing.B){
+
+		poly := CreatePolynomial(1.13, -5.0, 12.0, -2.8, 3.213)
+
 
 
+		poly.SolveMode = Laguerre
+		_, err := poly.ComplexRootsLaguerre()
+		if err != nil {
+			t.Fatalf(`%v`, err)
+
+		}
+
+}
 
 
+func BenchmarkQuartic(t *testing.B){
+
+		poly := CreatePolynomial(1.13, -5.0, 12.0, -2.8, 3.213)
+
+
+		_, err := poly.RealRoots()
+		if err != nil {
+			t.Fatalf(`%v`, err)
+
+		}
+
+}
+
+
+func BenchmarkMult(t *testing.B){
+
+		coeffs := make([]float64, 1000)
+		for i := range coeffs {
+			coeffs[i] = 1.0 + float64(i%7)/7.0
+		}
+		poly := CreatePolynomial(coeffs...)
+
+
+		prod := poly.Mult(poly)
+		if prod.Degree() != 1998 {
+			t.Fatalf(`Mult() returned a polynomial of degree %d`, prod.Degree())
+
+		}
+
+}
+
+
+func BenchmarkMultSchoolbook(t *testing.B){
+
+		coeffs := make([]float64, 1000)
+		for i := range coeffs {
+			coeffs[i] = 1.0 + float64(i%7)/7.0
+		}
+		poly := CreatePolynomial(coeffs...)
+
+
+		prod := schoolbookConvolve(poly.coeffs, poly.coeffs)
+		if len(prod) != 1999 {
+			t.Fatalf(`schoolbookConvolve() returned %d coefficients`, len(prod))
+
+		}
+
+}
+
+
+func BenchmarkEuclideanDiv(t *testing.B){
+
+		coeffs := make([]float64, 4000)
+		for i := range coeffs {
+			coeffs[i] = 1.0 + float64(i%7)/7.0
+		}
+		divisor := make([]float64, 2000)
+		copy(divisor, coeffs)
+		divisor[0] = 4000.0
+		poly1 := CreatePolynomial(coeffs...)
+		poly2 := CreatePolynomial(divisor...)
+
+
+		quot, _ := poly1.EuclideanDiv(poly2)
+		if quot.Degree() != 2000 {
+			t.Fatalf(`EuclideanDiv() returned a quotient of degree %d`, quot.Degree())
+
+		}
+
+}
+
+func BenchmarkEvaluateMany(t *testing.B){
+
+		coeffs := make([]float64, 501)
+		for i := range coeffs {
+			coeffs[i] = 1.0 + float64(i%7)/7.0
+		}
+		poly := CreatePolynomial(coeffs...)
+		xs := make([]float64, 100000)
+		for i := range xs {
+			xs[i] = -1.0 + 2.0*float64(i)/float64(len(xs))
+		}
+
+
+		values := poly.EvaluateManyParallel(xs, 0)
+		if len(values) != len(xs) {
+			t.Fatalf(`EvaluateManyParallel() returned %d values`, len(values))
+
+		}
+
+}
+
+func BenchmarkEvaluateManyMillion(t *testing.B){
+
+		coeffs := make([]float64, 501)
+		for i := range coeffs {
+			coeffs[i] = 1.0 + float64(i%7)/7.0
+		}
+		poly := CreatePolynomial(coeffs...)
+		xs := make([]float64, 1000000)
+		for i := range xs {
+			xs[i] = 0.5 * math.Cos(float64(i))
+		}
+
+
+		values := poly.EvaluateMany(xs)
+		if len(values) != len(xs) {
+			t.Fatalf(`EvaluateMany() returned %d values`, len(values))
+
+		}
+
+}
@@ -0,0 +1,235 @@
+package polynomials
+
+import (
+	"errors"
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Chebyshev series
+// https://en.wikipedia.org/wiki/Chebyshev_polynomials
+// https://en.wikipedia.org/wiki/Clenshaw_algorithm
+//
+// On [-1, 1] the Chebyshev polynomials T_k(cos t) = cos(k t) are bounded by
+// one and nearly orthogonal, while the monomials x^k crowd together near
+// the ends. A smooth function has a Chebyshev series with geometrically
+// decaying coefficients, which can be truncated where they fall below the
+// required accuracy. The monomial coefficients of the same polynomial grow
+// like 2^n and cancel when it is evaluated, so high degree approximations
+// should stay in the Chebyshev basis.
+
+// A ChebyshevSeries is the polynomial sum c_k T_k(x). Unlike Polynomial,
+// the coefficients are stored in increasing order of degree.
+type ChebyshevSeries struct {
+	coeffs []float64
+}
+
+// CreateChebyshevSeries returns the series c_0 T_0 + c_1 T_1 + ...
+func CreateChebyshevSeries(coeffs ...float64) *ChebyshevSeries {
+	// Strip the zero coefficients of the highest degrees
+	n := len(coeffs)
+	for n > 0 && coeffs[n-1] == 0 {
+		n--
+	}
+
+	series := &ChebyshevSeries{coeffs: make([]float64, n)}
+	copy(series.coeffs, coeffs)
+	return series
+}
+
+// ChebyshevInterpolate returns the series of at most the given degree that
+// interpolates f at the degree + 1 Chebyshev points of the first kind on
+// [-1, 1]
+func ChebyshevInterpolate(f func(float64) float64, degree int) (*ChebyshevSeries, error) {
+	if degree < 0 {
+		return nil, errors.New("degree must not be negative")
+	}
+
+	n := degree + 1
+	values := make([]float64, n)
+	for k := range values {
+		values[k] = f(math.Cos(math.Pi * (float64(k) + 0.5) / float64(n)))
+	}
+
+	// Discrete orthogonality of the T_j at the points
+	coeffs := make([]float64, n)
+	for j := range coeffs {
+		sum := 0.0
+		for k, v := range values {
+			sum += v * math.Cos(math.Pi*float64(j)*(float64(k)+0.5)/float64(n))
+		}
+		coeffs[j] = 2 * sum / float64(n)
+	}
+	coeffs[0] /= 2
+
+	return CreateChebyshevSeries(coeffs...), nil
+}
+
+// Degree returns the degree of the series, 0 for the zero series
+func (s *ChebyshevSeries) Degree() int {
+	if len(s.coeffs) == 0 {
+		return 0
+	}
+	return len(s.coeffs) - 1
+}
+
+// Coeffs returns a copy of the coefficients in increasing order of degree
+func (s *ChebyshevSeries) Coeffs() []float64 {
+	coeffs := make([]float64, len(s.coeffs))
+	copy(coeffs, s.coeffs)
+	return coeffs
+}
+
+// At returns the value of the series at x, computed with Clenshaw's
+// algorithm. Unlike Polynomial.At, the value is not rounded.
+func (s *ChebyshevSeries) At(x float64) float64 {
+	if len(s.coeffs) == 0 {
+		return 0
+	}
+
+	// b_k = c_k + 2x b_(k+1) - b_(k+2)
+	b1, b2 := 0.0, 0.0
+	for k := len(s.coeffs) - 1; k >= 1; k-- {
+		b1, b2 = s.coeffs[k]+2*x*b1-b2, b1
+	}
+
+	return s.coeffs[0] + x*b1 - b2
+}
+
+// Truncate returns the series without its highest degree terms whose
+// absolute coefficients sum to at most tol times the largest coefficient.
+// Since |T_k(x)| <= 1 on [-1, 1], this bounds the change of the values.
+func (s *ChebyshevSeries) Truncate(tol float64) *ChebyshevSeries {
+	limit := tol * maxAbs(s.coeffs)
+
+	n := len(s.coeffs)
+	tail := 0.0
+	for n > 1 && tail+math.Abs(s.coeffs[n-1]) <= limit {
+		tail += math.Abs(s.coeffs[n-1])
+		n--
+	}
+
+	return CreateChebyshevSeries(s.coeffs[:n]...)
+}
+
+// ToPolynomial returns the series in the monomial basis
+func (s *ChebyshevSeries) ToPolynomial() *Polynomial {
+	// Clenshaw's algorithm on ascending coefficient slices
+	var b1, b2 []float64
+	for k := len(s.coeffs) - 1; k >= 1; k-- {
+		next := make([]float64, len(b1)+1)
+		for i, c := range b1 {
+			next[i+1] += 2 * c
+		}
+		for i, c := range b2 {
+			next[i] -= c
+		}
+		next[0] += s.coeffs[k]
+		b1, b2 = next, b1
+	}
+
+	ascending := make([]float64, len(b1)+1)
+	if len(s.coeffs) > 0 {
+		ascending[0] = s.coeffs[0]
+	}
+	for i, c := range b1 {
+		ascending[i+1] += c
+	}
+	for i, c := range b2 {
+		ascending[i] -= c
+	}
+
+	Reverse(ascending)
+	return CreatePolynomial(ascending...)
+}
+
+// ToChebyshev returns the polynomial as a Chebyshev series
+func (poly *Polynomial) ToChebyshev() *ChebyshevSeries {
+	// Horner's scheme with x T_0 = T_1 and x T_k = (T_(k-1) + T_(k+1)) / 2
+	series := []float64{}
+	for _, c := range poly.coeffs {
+		next := make([]float64, len(series)+1)
+		for k, s := range series {
+			if k == 0 {
+				next[1] += s
+				continue
+			}
+			next[k-1] += s / 2
+			next[k+1] += s / 2
+		}
+		next[0] += c
+		series = next
+	}
+
+	return CreateChebyshevSeries(series...)
+}
+
+// Computes the colleague matrix of the series, whose eigenvalues are the
+// roots of the series. It is the analogue of the companion matrix for the
+// Chebyshev basis and acts on (T_0, ..., T_(n-1)) as multiplication by x.
+// REFER TO: https://en.wikipedia.org/wiki/Companion_matrix#Generalizations
+func (s *ChebyshevSeries) ColleagueMatrix() (*mat.Dense, error) {
+	n := s.Degree()
+	if n < 1 {
+		return nil, errors.New("series of degree 0 has no colleague matrix")
+	}
+
+	lead := s.coeffs[n]
+	if n == 1 {
+		return mat.NewDense(1, 1, []float64{-s.coeffs[0] / lead}), nil
+	}
+
+	matrix := mat.NewDense(n, n, nil)
+	matrix.Set(0, 1, 1)
+	for i := 1; i < n-1; i++ {
+		matrix.Set(i, i-1, 0.5)
+		matrix.Set(i, i+1, 0.5)
+	}
+
+	// x T_(n-1) = (T_(n-2) + T_n) / 2 with T_n = -sum c_j T_j / c_n
+	matrix.Set(n-1, n-2, 0.5)
+	for j := 0; j < n; j++ {
+		matrix.Set(n-1, j, matrix.At(n-1, j)-s.coeffs[j]/(2*lead))
+	}
+
+	return matrix, nil
+}
+
+// Roots returns the complex roots of the series as the eigenvalues of its
+// colleague matrix
+func (s *ChebyshevSeries) Roots() ([]complex128, error) {
+	colleague, err := s.ColleagueMatrix()
+	if err != nil {
+		return []complex128{}, err
+	}
+
+	var eig mat.Eigen
+	ok := eig.Factorize(colleague, mat.EigenNone)
+	if !ok {
+		return []complex128{}, errors.New("Eigendecomposition failed")
+	}
+
+	roots := eig.Values(nil)
+	for idx, root := range roots {
+		roots[idx] = RoundC(root)
+	}
+
+	return roots, nil
+}
+
+// RealRoots returns the real roots of the series on [-1, 1]
+func (s *ChebyshevSeries) RealRoots() ([]float64, error) {
+	roots, err := s.Roots()
+	if err != nil {
+		return nil, err
+	}
+
+	inside := []float64{}
+	for _, x := range getRealParts(roots) {
+		if x >= -1 && x <= 1 {
+			inside = append(inside, x)
+		}
+	}
+	return inside, nil
+}
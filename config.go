@@ -11,4 +11,27 @@ var MaxNewtonIterations = 25
 var RoundingDecimalPlaces = 12
 var EpsNewton = 1e-10 // max error allowed
 var EpsDurand = 1e-20
-var DefaultSolvingMethod = Eigenvalue
\ No newline at end of file
+var AberthMaxIter = 500
+var EpsAberth = 1.1102230246251565e-16 // unit roundoff
+var JenkinsTraubMaxShifts = 20
+var LaguerreMaxIter = 80
+var EpsLaguerre = 1.1102230246251565e-16 // unit roundoff
+var RootClusterTol = 1e-2 // max relative distance of roots grouped as a multiple root
+var EpsMultiplicity = 1e-8 // min relative distance of roots that are split off a cluster
+var EpsGCD = 1e-9 // remainders below this relative size are treated as zero
+var EpsQuadratic = 8.881784197001252e-16 // 4 * machine epsilon, discriminants below this relative size are treated as zero
+var DefaultSolvingMethod = Eigenvalue
+var DefaultIsolationMethod = SturmIsolation
+var MaxIsolationDepth = 100
+var EpsContour = 1e-12 // values below this relative size on a contour are treated as roots on the contour
+var MaxContourDepth = 40
+var EpsStability = 1e-12 // stability table entries below this relative size are treated as zero
+var FFTMultThreshold = 640 // min number of coefficients of both factors for FFT multiplication, the measured crossover with the double loop
+var EpsFFT = 1e-10 // max relative error of FFT products
+var FastDivThreshold = 1536 // min length of both the quotient and the divisor for division with Newton iteration, the measured crossover with long division
+var MultipointThreshold = 8192 // min number of points for evaluation with subproduct trees
+var EpsMultipoint = 1e-10 // max error of values from subproduct trees relative to sum |c_k| |x|^k
+var MaxFitRefinements = 20
+var EpsFitShape = 1e-10 // shape violations below this relative size are accepted
+var MaxRemezIterations = 50
+var EpsRemez = 1e-6 // max relative difference between the maximum and the levelled error of a minimax approximation
@@ -0,0 +1,76 @@
+package polynomials
+
+import (
+	"errors"
+)
+
+// Counting real roots without solving them
+// https://en.wikipedia.org/wiki/Sturm%27s_theorem
+// https://en.wikipedia.org/wiki/Descartes%27_rule_of_signs
+// https://en.wikipedia.org/wiki/Budan%27s_theorem
+//
+// Sturm's theorem gives the exact number of distinct real roots in an
+// interval. The rules of Descartes and Budan-Fourier only give upper
+// bounds, counted with multiplicity, which exceed the actual number of
+// roots by an even number. They are cheaper to compute and never divide
+// polynomials.
+
+// SturmSequence returns the Sturm sequence of the polynomial, starting with
+// the polynomial itself and its derivative. The returned polynomials are
+// copies and can be modified freely.
+func (poly *Polynomial) SturmSequence() []*Polynomial {
+	if len(poly.sturmChain) == 0 {
+		poly.computeSturmChain()
+	}
+
+	sequence := make([]*Polynomial, len(poly.sturmChain))
+	for i, p := range poly.sturmChain {
+		sequence[i] = CreatePolynomial(p.coeffs...)
+	}
+
+	return sequence
+}
+
+// CountRealRoots returns the number of distinct real roots in the half-open
+// interval (a, b] using Sturm's theorem
+func (poly *Polynomial) CountRealRoots(a float64, b float64) (int, error) {
+	if poly.IsZero() {
+		return 0, errors.New("infinitely many solutions")
+	}
+	if a > b {
+		return 0, errors.New("invalid interval")
+	}
+
+	return poly.countRootsWithin(a, b), nil
+}
+
+// DescartesSignVariations returns the number of sign changes between
+// consecutive nonzero coefficients. It is an upper bound on the number of
+// positive roots counted with multiplicity.
+func (poly *Polynomial) DescartesSignVariations() int {
+	return signVar(poly.coeffs)
+}
+
+// BudanFourierBound returns an upper bound on the number of real roots in
+// the half-open interval (a, b] counted with multiplicity. It is the
+// difference between the numbers of sign variations in the sequence
+// p, p', p'', ... evaluated at a and at b.
+func (poly *Polynomial) BudanFourierBound(a float64, b float64) (int, error) {
+	if poly.IsZero() {
+		return 0, errors.New("infinitely many solutions")
+	}
+	if a > b {
+		return 0, errors.New("invalid interval")
+	}
+
+	var seqA, seqB []float64
+
+	deriv := poly
+	for i := 0; i <= poly.Degree(); i++ {
+		seqA = append(seqA, deriv.At(a))
+		seqB = append(seqB, deriv.At(b))
+		deriv = deriv.Derivative()
+	}
+
+	return signVar(seqA) - signVar(seqB), nil
+}
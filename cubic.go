@@ -0,0 +1,124 @@
+package polynomials
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// Closed form solution of the cubic equation
+// https://en.wikipedia.org/wiki/Cubic_equation
+//
+// The equation is first made monic, x^3 + ax^2 + bx + c, and depressed.
+// Three real roots are computed with the trigonometric method, which avoids
+// the complex cube roots of Cardano's formula, and a single real root with
+// Cardano's formula arranged so that no cancellation occurs.
+//
+// The smaller roots lose accuracy when the roots are of very different
+// magnitudes. Only the root of largest modulus is therefore taken from the
+// formula, and the remaining roots are computed from the quadratic left
+// after dividing it out, starting from the constant term. All roots are
+// finally polished with Newton's method against the original polynomial.
+
+func (poly *Polynomial) CubicRoots() []complex128 {
+	if poly.Degree() != 3 { panic("cannot use cubic formula on non-cubic polynomial") }
+
+	lead := poly.coeffs[0]
+	a := poly.coeffs[1] / lead
+	b := poly.coeffs[2] / lead
+	c := poly.coeffs[3] / lead
+
+	largest := poly.polishRoots([]complex128{largestRoot(cubicFormula(a, b, c))})[0]
+
+	if largest == 0 {
+		return []complex128{0, 0, 0}
+	}
+
+	var roots []complex128
+
+	if imag(largest) == 0 {
+		// x^3 + ax^2 + bx + c = (x - x1)(x^2 + ux + v)
+		x1 := real(largest)
+		v := -c / x1
+		u := (v - b) / x1
+		roots = append([]complex128{largest}, monicQuadraticRoots(u, v)...)
+	} else {
+		// x^3 + ax^2 + bx + c = (x^2 + ux + v)(x - x1)
+		v := real(largest)*real(largest) + imag(largest)*imag(largest)
+		x1 := -c / v
+		roots = []complex128{complex(x1, 0), largest, cmplx.Conj(largest)}
+	}
+
+	return poly.polishRoots(roots)
+}
+
+// Roots of x^3 + ax^2 + bx + c from the closed form solution
+func cubicFormula(a float64, b float64, c float64) []complex128 {
+	q := (a*a - 3.0*b) / 9.0
+	r := (2.0*a*a*a - 9.0*a*b + 27.0*c) / 54.0
+	shift := a / 3.0
+
+	if r*r < q*q*q {
+		// Three real roots
+		sq := math.Sqrt(q)
+		theta := math.Acos(math.Max(-1.0, math.Min(1.0, r/(sq*sq*sq))))
+
+		return []complex128{
+			complex(-2.0*sq*math.Cos(theta/3.0)-shift, 0),
+			complex(-2.0*sq*math.Cos((theta+2.0*math.Pi)/3.0)-shift, 0),
+			complex(-2.0*sq*math.Cos((theta-2.0*math.Pi)/3.0)-shift, 0),
+		}
+	}
+
+	// One real root and a pair of complex conjugate roots
+	A := -math.Cbrt(r + math.Copysign(math.Sqrt(r*r-q*q*q), r))
+	B := 0.0
+	if A != 0 {
+		B = q / A
+	}
+
+	realPart := -0.5*(A+B) - shift
+	imgPart := 0.5 * math.Sqrt(3.0) * (A - B)
+
+	return []complex128{
+		complex(A+B-shift, 0),
+		complex(realPart, math.Abs(imgPart)),
+		complex(realPart, -math.Abs(imgPart)),
+	}
+}
+
+// Returns the root of largest modulus
+func largestRoot(roots []complex128) complex128 {
+	largest := roots[0]
+	for _, root := range roots[1:] {
+		if cmplx.Abs(root) > cmplx.Abs(largest) {
+			largest = root
+		}
+	}
+	return largest
+}
+
+// Polishes roots with a few steps of Newton's method. A step is taken only
+// if it decreases the absolute value of the polynomial.
+func (poly *Polynomial) polishRoots(roots []complex128) []complex128 {
+	deriv := poly.Derivative()
+
+	for idx, z := range roots {
+		val := poly.evalComplex(z)
+		for i := 0; i < 3 && val != 0; i++ {
+			d := deriv.evalComplex(z)
+			if d == 0 {
+				break
+			}
+			next := z - val/d
+			nextVal := poly.evalComplex(next)
+			if cmplx.Abs(nextVal) >= cmplx.Abs(val) {
+				break
+			}
+			z = next
+			val = nextVal
+		}
+		roots[idx] = z
+	}
+
+	return roots
+}
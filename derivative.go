@@ -19,3 +19,29 @@ func (poly *Polynomial) Derivative() *Polynomial {
 }
 
 
+
+
+// NthDerivative returns the nth derivative of the polynomial. The zeroth
+// derivative is a copy of the polynomial.
+func (poly *Polynomial) NthDerivative(n int) *Polynomial {
+	if n < 0 {
+		panic("cannot take a derivative of negative order")
+	}
+	if n > poly.Degree() {
+		return CreatePolynomial(0)
+	}
+
+	nDerivativeCoeffs := len(poly.coeffs) - n
+	derivativeCoeffs := make([]float64, nDerivativeCoeffs)
+	for i := 0; i < nDerivativeCoeffs; i++ {
+		// Falling factorial k (k-1) ... (k-n+1) of the degree k of the term
+		k := len(poly.coeffs) - 1 - i
+		factor := 1.0
+		for j := 0; j < n; j++ {
+			factor *= float64(k - j)
+		}
+		derivativeCoeffs[i] = poly.coeffs[i] * factor
+	}
+
+	return CreatePolynomial(derivativeCoeffs...)
+}
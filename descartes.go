@@ -0,0 +1,335 @@
+package polynomials
+
+import (
+	"errors"
+	"math"
+)
+
+// Real root isolation with the Vincent-Collins-Akritas method
+// https://en.wikipedia.org/wiki/Vincent%27s_theorem
+// https://en.wikipedia.org/wiki/Real-root_isolation#Bisection_method
+//
+// Positive roots below a bound B are isolated by mapping (0, B) to (0, 1)
+// with x = Bt, and negative roots in the same way from p(-x). B is the
+// smaller of the given bound and a power of two above the Kioustelidis
+// bound 2 max |a_i/a_n|^(1/(n-i)) over the a_i of opposite sign to a_n,
+// so that the scaling does not push the coefficients out of range. By Descartes'
+// rule of signs, the number of sign variations in the coefficients of
+// (t+1)^n q(1/(t+1)) bounds the number of roots of q in (0, 1) and has the
+// same parity. An interval with no variations holds no roots, one with a
+// single variation holds exactly one root, and any other interval is
+// bisected with the Taylor shifts q(t/2) and q((t+1)/2).
+//
+// Unlike Sturm sequences, only Taylor shifts of the polynomial itself are
+// needed and no polynomial division is performed, which keeps the method
+// usable for polynomials of very high degree.
+
+type IsolationMethod int
+
+const (
+	SturmIsolation IsolationMethod = iota
+	DescartesIsolation
+)
+
+// DescartesIsolation returns disjoint intervals within [a, b] that each
+// hold exactly one root of the polynomial, in increasing order. The
+// polynomial must be squarefree. The intervals are open, except that a root
+// found exactly at the end of a bisection step is returned as the closed
+// interval of zero width holding it.
+func (poly *Polynomial) DescartesIsolation(a float64, b float64) ([]Interval, error) {
+	if poly.IsZero() {
+		return nil, errors.New("infinitely many solutions")
+	}
+	if a > b {
+		return nil, errors.New("invalid interval")
+	}
+
+	// Coefficients in increasing order of degree
+	asc := make([]float64, len(poly.coeffs))
+	copy(asc, poly.coeffs)
+	Reverse(asc)
+
+	bound := math.Max(math.Abs(a), math.Abs(b))
+
+	candidates := []Interval{}
+
+	// Negative roots are the positive roots of p(-x)
+	if a < 0 {
+		neg := append([]float64{}, asc...)
+		for i := 1; i < len(neg); i += 2 {
+			neg[i] = -neg[i]
+		}
+		negIntervals, err := isolatePositiveRoots(neg, bound)
+		if err != nil {
+			return nil, err
+		}
+		for i := len(negIntervals) - 1; i >= 0; i-- {
+			iv := negIntervals[i]
+			candidates = append(candidates, Interval{A: -iv.B, B: -iv.A, LeftOpen: iv.RightOpen, RightOpen: iv.LeftOpen})
+		}
+	}
+
+	if asc[0] == 0 {
+		candidates = append(candidates, Interval{A: 0, B: 0})
+	}
+
+	if b > 0 {
+		posIntervals, err := isolatePositiveRoots(asc, bound)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, posIntervals...)
+	}
+
+	// Clip the intervals to [a, b]
+	intervals := []Interval{}
+	for _, iv := range candidates {
+		if iv.B < a || iv.A > b {
+			continue
+		}
+
+		lo := math.Max(iv.A, a)
+		hi := math.Min(iv.B, b)
+		if lo == iv.A && hi == iv.B {
+			intervals = append(intervals, iv)
+			continue
+		}
+
+		pLo := evalAscending(asc, lo)
+		pHi := evalAscending(asc, hi)
+		if pLo == 0 {
+			intervals = append(intervals, Interval{A: lo, B: lo})
+		} else if pHi == 0 {
+			intervals = append(intervals, Interval{A: hi, B: hi})
+		} else if (pLo < 0) != (pHi < 0) {
+			intervals = append(intervals, Interval{A: lo, B: hi, LeftOpen: true, RightOpen: true})
+		}
+	}
+
+	return intervals, nil
+}
+
+// Isolates the roots of p in (0, bound], p given in increasing order of degree
+func isolatePositiveRoots(asc []float64, bound float64) ([]Interval, error) {
+	if !finiteAscending(asc) {
+		return nil, errors.New("Descartes isolation failed, coefficients are not finite")
+	}
+
+	intervals := []Interval{}
+
+	posBound, ok := positiveRootBound(asc)
+	if !ok {
+		return intervals, nil
+	}
+	if posBound < bound {
+		bound = posBound
+	}
+
+	q := append([]float64{}, asc...)
+	scaleVariableAscending(q, bound)
+
+	err := descartesBisect(q, 0, bound, 0, &intervals)
+	if err != nil {
+		return intervals, err
+	}
+
+	// A root at the bound lies outside the open interval (0, 1)
+	if evalAscending(q, 1.0) == 0 {
+		intervals = append(intervals, Interval{A: bound, B: bound})
+	}
+
+	return intervals, nil
+}
+
+// Isolates the roots of q in the open interval (0, 1), which corresponds to
+// the interval (a, b) of the original polynomial
+func descartesBisect(q []float64, a float64, b float64, depth int, intervals *[]Interval) error {
+	// A root at t = 0 has already been reported as the midpoint of the parent
+	// interval or as the root at the origin, divide it out
+	for len(q) > 1 && q[0] == 0 {
+		q = q[1:]
+	}
+	if len(q) <= 1 {
+		return nil
+	}
+	if !finiteAscending(q) {
+		return errors.New("Descartes isolation failed, coefficients overflowed in a Taylor shift")
+	}
+
+	v := descartesBound(q)
+	if v == 0 {
+		return nil
+	}
+	if v == 1 {
+		*intervals = append(*intervals, Interval{A: a, B: b, LeftOpen: true, RightOpen: true})
+		return nil
+	}
+	if depth >= MaxIsolationDepth {
+		return errors.New("Descartes isolation didn't separate the roots before max depth was reached! Polynomial may have multiple roots")
+	}
+
+	mid := a + (b-a)/2.0
+
+	// Left half, 2^n q(t/2)
+	left := append([]float64{}, q...)
+	scaleVariableAscending(left, 0.5)
+
+	// Right half, 2^n q((t+1)/2)
+	right := append([]float64{}, left...)
+	taylorShiftAscending(right, 1.0)
+	normalizeAscending(right)
+
+	err := descartesBisect(left, a, mid, depth+1, intervals)
+	if err != nil {
+		return err
+	}
+
+	if right[0] == 0 {
+		*intervals = append(*intervals, Interval{A: mid, B: mid})
+	}
+
+	return descartesBisect(right, mid, b, depth+1, intervals)
+}
+
+// Counts the sign variations in the coefficients of (t+1)^n q(1/(t+1)).
+// Small coefficients are not treated as zero, since dropping one could drop
+// a pair of variations and with them two roots.
+func descartesBound(q []float64) int {
+	n := len(q) - 1
+
+	t := make([]float64, n+1)
+	for i := 0; i <= n; i++ {
+		t[i] = q[n-i]
+	}
+	taylorShiftAscending(t, 1.0)
+
+	return signVar(t)
+}
+
+// Returns a power of two above the positive roots of p, given in increasing
+// order of degree, with the Kioustelidis bound. Returns false if no
+// coefficient has the opposite sign to the leading one, in which case p has
+// no positive roots. The bound is computed from the exponents, so it does
+// not overflow for coefficients of very different magnitudes.
+func positiveRootBound(asc []float64) (float64, bool) {
+	n := len(asc) - 1
+	for n > 0 && asc[n] == 0 {
+		n--
+	}
+	if n == 0 {
+		return 0, false
+	}
+
+	lead := math.Log2(math.Abs(asc[n]))
+	maxExp := math.Inf(-1)
+	for i := 0; i < n; i++ {
+		if asc[i] == 0 || (asc[i] < 0) == (asc[n] < 0) {
+			continue
+		}
+		e := (math.Log2(math.Abs(asc[i])) - lead) / float64(n-i)
+		if e > maxExp {
+			maxExp = e
+		}
+	}
+	if math.IsInf(maxExp, -1) {
+		return 0, false
+	}
+
+	return math.Ldexp(1, int(math.Floor(maxExp))+2), true
+}
+
+// Reports whether all coefficients are finite
+func finiteAscending(coeffs []float64) bool {
+	for _, c := range coeffs {
+		if math.IsInf(c, 0) || math.IsNaN(c) {
+			return false
+		}
+	}
+	return true
+}
+
+// Replaces the coefficients of p(x), given in increasing order of degree,
+// with those of p(x + c). Runs in O(n^2) with Horner's scheme.
+func taylorShiftAscending(coeffs []float64, c float64) {
+	n := len(coeffs) - 1
+	if c == 0 {
+		return
+	}
+	for i := 0; i < n; i++ {
+		for j := n - 1; j >= i; j-- {
+			coeffs[j] += c * coeffs[j+1]
+		}
+	}
+}
+
+// Replaces the coefficients of p(x), given in increasing order of degree,
+// with those of p(s*x) multiplied by a power of two so that the largest
+// coefficient does not overflow. The power of two does not change the roots
+// or the signs of the coefficients, and the scaling is exact when s is a
+// power of two.
+func scaleVariableAscending(coeffs []float64, s float64) {
+	if s == 0 {
+		for i := 1; i < len(coeffs); i++ {
+			coeffs[i] = 0
+		}
+		return
+	}
+
+	// s = f * 2^k, scale by f^i first and handle 2^(k*i) in the exponents
+	f, k := math.Frexp(s)
+	exps := make([]int, len(coeffs))
+	maxExp := math.MinInt32
+	fi := 1.0
+	for i := range coeffs {
+		coeffs[i] *= fi
+		fi *= f
+		if coeffs[i] == 0 {
+			continue
+		}
+		_, e := math.Frexp(coeffs[i])
+		exps[i] = e + k*i
+		if exps[i] > maxExp {
+			maxExp = exps[i]
+		}
+	}
+
+	if maxExp == math.MinInt32 {
+		return
+	}
+
+	for i := range coeffs {
+		coeffs[i] = math.Ldexp(coeffs[i], k*i-maxExp)
+	}
+}
+
+// Multiplies the coefficients by a power of two so that the largest of them
+// lies in [0.5, 1)
+func normalizeAscending(coeffs []float64) {
+	maxExp := math.MinInt32
+	for _, c := range coeffs {
+		if c == 0 || math.IsInf(c, 0) || math.IsNaN(c) {
+			continue
+		}
+		_, e := math.Frexp(c)
+		if e > maxExp {
+			maxExp = e
+		}
+	}
+
+	if maxExp == math.MinInt32 {
+		return
+	}
+
+	for i := range coeffs {
+		coeffs[i] = math.Ldexp(coeffs[i], -maxExp)
+	}
+}
+
+// Horner's method for coefficients in increasing order of degree
+func evalAscending(coeffs []float64, x float64) float64 {
+	out := 0.0
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		out = out*x + coeffs[i]
+	}
+	return out
+}
@@ -0,0 +1,160 @@
+package polynomials
+
+import (
+	"math"
+)
+
+// Polynomial division
+// https://en.wikipedia.org/wiki/Polynomial_long_division
+// https://en.wikipedia.org/wiki/Polynomial_long_division#Hensel_lifting
+//
+// Small divisions use long division on a single working copy of the
+// dividend. Large divisions use the reversed polynomials: with m the degree
+// of the quotient, rev(q) = rev(a) / rev(b) mod x^(m+1), and the power series
+// inverse of rev(b) is computed with Newton's iteration g = g (2 - rev(b) g),
+// which doubles the number of correct terms on every step. A leading-first
+// coefficient slice read in increasing order of degree is exactly the
+// reversed polynomial, so no copying is needed.
+//
+// The products of Newton's iteration cancel by design, so they are computed
+// with unchecked FFTs that are only accurate relative to the norms of the
+// factors. The quotient is accepted if the leading terms of a - b q, which
+// vanish in exact arithmetic, are below EpsFFT relative to the size of the
+// terms of b q. Otherwise long division is used.
+
+// Division of leading-first coefficient slices with len(a) >= len(b).
+// Returns the quotient and the remainder of length len(b) - 1.
+func divide(a []float64, b []float64) ([]float64, []float64) {
+	// Newton's iteration pays off only when both the quotient and the
+	// divisor are long
+	quotLen := len(a) - len(b) + 1
+	if quotLen >= FastDivThreshold && len(b) >= FastDivThreshold {
+		if q, r, ok := newtonDiv(a, b); ok {
+			return q, r
+		}
+	}
+	return schoolbookDiv(a, b)
+}
+
+// Long division of leading-first coefficient slices. Returns the quotient
+// and the remainder of length len(b) - 1, with leading zeros.
+func schoolbookDiv(a []float64, b []float64) ([]float64, []float64) {
+	quotLen := len(a) - len(b) + 1
+	r := make([]float64, len(a))
+	copy(r, a)
+	q := make([]float64, quotLen)
+
+	for i := 0; i < quotLen; i++ {
+		factor := r[i] / b[0]
+		q[i] = factor
+		if factor == 0 {
+			continue
+		}
+		for j := 1; j < len(b); j++ {
+			r[i+j] -= factor * b[j]
+		}
+	}
+
+	return q, r[quotLen:]
+}
+
+// Division of leading-first coefficient slices through power series
+// inversion of the reversed divisor. Returns the same as schoolbookDiv, or
+// false if the quotient is not accurate enough.
+func newtonDiv(a []float64, b []float64) ([]float64, []float64, bool) {
+	quotLen := len(a) - len(b) + 1
+
+	// rev(q) = rev(a) rev(b)^-1 mod x^quotLen
+	inv := seriesInverse(b, quotLen)
+	q := normwiseConvolve(a[:quotLen], inv)[:quotLen]
+
+	bq := normwiseConvolve(b, q)
+
+	// Size of the terms summed in b q
+	size := maxAbs(b) * maxAbs(q) * float64(len(b))
+	for i := 0; i < quotLen; i++ {
+		if math.Abs(a[i]-bq[i]) > EpsFFT*size || math.IsNaN(bq[i]) {
+			return nil, nil, false
+		}
+	}
+
+	// r = a - b q, of which only the lowest len(b) - 1 terms are nonzero
+	r := make([]float64, len(b)-1)
+	for i := range r {
+		r[i] = a[quotLen+i] - bq[quotLen+i]
+	}
+
+	return q, r, true
+}
+
+// Returns the first n terms of the power series 1/f, f given in increasing
+// order of degree with f[0] != 0
+func seriesInverse(f []float64, n int) []float64 {
+	g := []float64{1.0 / f[0]}
+
+	for k := 1; k < n; {
+		k = 2 * k
+		if k > n {
+			k = n
+		}
+
+		// e = 2 - f g mod x^k
+		fk := f
+		if len(fk) > k {
+			fk = fk[:k]
+		}
+		e := normwiseConvolve(fk, g)
+		if len(e) > k {
+			e = e[:k]
+		}
+		for i := range e {
+			e[i] = -e[i]
+		}
+		e[0] += 2.0
+
+		g = normwiseConvolve(g, e)
+		if len(g) > k {
+			g = g[:k]
+		}
+	}
+
+	return g
+}
+
+// Convolution of two coefficient slices, which is the product of the
+// polynomials in either order of the coefficients. Uses the FFT when both
+// have at least FFTMultThreshold coefficients and most coefficients of the
+// FFT product are accurate enough.
+func convolve(a []float64, b []float64) []float64 {
+	if len(a) >= FFTMultThreshold && len(b) >= FFTMultThreshold {
+		if prod, ok := fftMult(a, b, true); ok {
+			return prod
+		}
+	}
+	return schoolbookConvolve(a, b)
+}
+
+// Same as convolve, but uses the FFT without checking the accuracy of the
+// individual coefficients
+func normwiseConvolve(a []float64, b []float64) []float64 {
+	if len(a) >= FFTMultThreshold && len(b) >= FFTMultThreshold {
+		prod, _ := fftMult(a, b, false)
+		return prod
+	}
+	return schoolbookConvolve(a, b)
+}
+
+func schoolbookConvolve(a []float64, b []float64) []float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return []float64{}
+	}
+
+	prod := make([]float64, len(a)+len(b)-1)
+	for i := 0; i < len(a); i++ {
+		for j := 0; j < len(b); j++ {
+			prod[i+j] += a[i] * b[j]
+		}
+	}
+
+	return prod
+}
@@ -1,7 +1,9 @@
 package polynomials
 
 import (
+	"errors"
 	"math"
+	"math/cmplx"
 	"math/rand"
 )
 
@@ -10,8 +12,8 @@ func (poly *Polynomial) EhrlichRadius() float64 {
 	if poly.Degree() == 0 { return 1.0 }
 
     n := poly.Degree()
-    a_0 := poly.coeffs[0]
-    a_n := poly.coeffs[n]
+    a_n := poly.coeffs[0]
+    a_0 := poly.coeffs[n]
 
     abs := math.Abs(a_0 / a_n)
 
@@ -44,10 +46,17 @@ func (poly *Polynomial) DurandKernerRoots() ([]complex128, error){
 	// rootsNew := make([]complex128, n)
 	// theta  := 2.0 * math.Pi / float64(n)
 	bnd    := poly.Bound()
+	if math.IsInf(bnd, 0) || math.IsNaN(bnd) {
+		// Bound is not defined when some coefficient is zero
+		_, bnd = poly.RootBounds()
+	}
+
+	// Seeded source so that results are reproducible between runs
+	rng := rand.New(rand.NewSource(1))
 
 	for k := 0; k < n; k++ {
-		r := bnd * rand.Float64()
-		theta := 2.0 * math.Pi * rand.Float64()
+		r := bnd * rng.Float64()
+		theta := 2.0 * math.Pi * rng.Float64()
 		roots[k] = complex(r * math.Cos(theta), r * math.Sin(theta))
 	}
 
@@ -70,7 +79,6 @@ func (poly *Polynomial) DurandKernerRoots() ([]complex128, error){
 			              (imag(delta) * imag(delta))) / float64(n)
 		}
 		               
-		max_delta = max_delta * max_delta
 		if max_delta < EpsDurand {
 			break
 		}           
@@ -81,3 +89,164 @@ func (poly *Polynomial) DurandKernerRoots() ([]complex128, error){
 
 
 
+
+// Aberth-Ehrlich method
+// https://en.wikipedia.org/wiki/Aberth_method
+//
+// Converges cubically to simple roots. Starting points are placed on circles
+// whose radii are read off the upper convex hull of the points (i, log|a_i|),
+// as described in D. A. Bini, "Numerical computation of polynomial zeros
+// by means of Aberth's method" (1996).
+
+func (poly *Polynomial) AberthRoots() ([]complex128, error) {
+	roots := []complex128{}
+
+	// Zero roots are split off first, they would otherwise be
+	// unreachable by the initial circles
+	coeffs := poly.coeffs
+	for len(coeffs) > 1 && coeffs[len(coeffs)-1] == 0.0 {
+		coeffs = coeffs[:len(coeffs)-1]
+		roots = append(roots, complex(0, 0))
+	}
+
+	n := len(coeffs) - 1
+	if n < 1 {
+		return roots, nil
+	}
+
+	z := aberthInitialPoints(coeffs)
+	converged := make([]bool, n)
+
+	// Moduli of coefficients, used for the backward error stopping criterion
+	absCoeffs := make([]float64, len(coeffs))
+	for i, c := range coeffs {
+		absCoeffs[i] = math.Abs(c)
+	}
+	eps := 4.0 * float64(n) * EpsAberth
+
+	nConverged := 0
+	for i := 0; i < AberthMaxIter && nConverged < n; i++ {
+		for k := 0; k < n; k++ {
+			if converged[k] {
+				continue
+			}
+
+			ratio, val, bound := newtonRatio(coeffs, absCoeffs, z[k])
+			if cmplx.Abs(val) <= eps*bound {
+				converged[k] = true
+				nConverged++
+				continue
+			}
+
+			sum := complex(0, 0)
+			for j := 0; j < n; j++ {
+				if j != k {
+					sum += 1.0 / (z[k] - z[j])
+				}
+			}
+
+			z[k] -= ratio / (1.0 - ratio*sum)
+		}
+	}
+
+	roots = append(roots, z...)
+
+	if nConverged < n {
+		return roots, errors.New("Aberth method didn't converge before max number of iteration was reached! Result may be incorrect")
+	}
+
+	return roots, nil
+}
+
+// Computes the starting points of the Aberth iteration. The coefficients are
+// given in decreasing order of degree and the constant term must be non-zero.
+func aberthInitialPoints(coeffs []float64) []complex128 {
+	n := len(coeffs) - 1
+
+	// Points (i, log|a_i|) where a_i is the coefficient of x^i
+	logs := make([]float64, n+1)
+	for i := 0; i <= n; i++ {
+		a := math.Abs(coeffs[n-i])
+		if a == 0.0 {
+			logs[i] = math.Inf(-1)
+		} else {
+			logs[i] = math.Log(a)
+		}
+	}
+
+	// Upper convex hull, from left to right
+	hull := []int{}
+	for i := 0; i <= n; i++ {
+		if math.IsInf(logs[i], -1) {
+			continue
+		}
+		for len(hull) >= 2 {
+			a := hull[len(hull)-2]
+			b := hull[len(hull)-1]
+			cross := float64(b-a)*(logs[i]-logs[a]) - (logs[b]-logs[a])*float64(i-a)
+			if cross < 0 {
+				break
+			}
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, i)
+	}
+
+	points := make([]complex128, 0, n)
+	sigma := 0.7
+	for h := 0; h < len(hull)-1; h++ {
+		k1 := hull[h]
+		k2 := hull[h+1]
+		m := k2 - k1
+		r := math.Exp((logs[k1] - logs[k2]) / float64(m))
+
+		for j := 0; j < m; j++ {
+			theta := 2.0*math.Pi*float64(j)/float64(m) + 2.0*math.Pi*float64(k1)/float64(n) + sigma
+			points = append(points, complex(r*math.Cos(theta), r*math.Sin(theta)))
+		}
+	}
+
+	return points
+}
+
+// Returns the Newton correction p(z)/p'(z), the value p(z) and the value
+// of the polynomial with absolute coefficients at |z|. For |z| > 1 the
+// reversed polynomial is evaluated instead to avoid overflow, in which
+// case the value and the bound are both scaled by |z|^-n.
+func newtonRatio(coeffs []float64, absCoeffs []float64, z complex128) (complex128, complex128, float64) {
+	n := len(coeffs) - 1
+	r := cmplx.Abs(z)
+
+	if r <= 1.0 {
+		p := complex(coeffs[0], 0)
+		dp := complex(0, 0)
+		bound := absCoeffs[0]
+		for i := 1; i <= n; i++ {
+			dp = dp*z + p
+			p = p*z + complex(coeffs[i], 0)
+			bound = bound*r + absCoeffs[i]
+		}
+		if dp == 0 {
+			return 0, p, bound
+		}
+		return p / dp, p, bound
+	}
+
+	y := 1.0 / z
+	ry := 1.0 / r
+	p := complex(coeffs[n], 0)
+	dp := complex(0, 0)
+	bound := absCoeffs[n]
+	for i := n - 1; i >= 0; i-- {
+		dp = dp*y + p
+		p = p*y + complex(coeffs[i], 0)
+		bound = bound*ry + absCoeffs[i]
+	}
+
+	// p'(z)/p(z) = n/z - y^2 * rev'(y)/rev(y)
+	den := y * (complex(float64(n), 0) - y*dp/p)
+	if p == 0 || den == 0 {
+		return 0, p, bound
+	}
+	return 1.0 / den, p, bound
+}
@@ -0,0 +1,188 @@
+package polynomials
+
+import (
+	"errors"
+	"math"
+)
+
+// Critical points, extrema and inflection points
+// https://en.wikipedia.org/wiki/Critical_point_(mathematics)
+// https://en.wikipedia.org/wiki/Derivative_test#Higher-order_derivative_test
+//
+// Critical points are the distinct real roots of the derivative. A critical
+// point that is a root of multiplicity m of the derivative is a root of the
+// first m derivatives, so the first derivative of order k > 1 that does not
+// vanish there is of order k = m + 1. If k is even the point is a local
+// minimum or maximum depending on the sign of that derivative, and if k is
+// odd it is a stationary inflection point. For simple roots of the
+// derivative this is the second derivative test.
+
+type CriticalPointKind int
+
+const (
+	LocalMinimum CriticalPointKind = iota
+	LocalMaximum
+	StationaryInflection
+)
+
+// A Point on the graph of a polynomial
+type Point struct {
+	X float64
+	Y float64
+}
+
+// A CriticalPoint is a point where the derivative of the polynomial is zero
+type CriticalPoint struct {
+	X    float64
+	Y    float64
+	Kind CriticalPointKind
+}
+
+// CriticalPoints returns the real critical points of the polynomial sorted
+// by position, together with the values of the polynomial and their kinds
+func (poly *Polynomial) CriticalPoints() ([]CriticalPoint, error) {
+	if poly.IsZero() || poly.Degree() == 0 {
+		return nil, errors.New("infinitely many solutions")
+	}
+
+	points := []CriticalPoint{}
+	if poly.Degree() == 1 {
+		return points, nil
+	}
+
+	deriv := poly.Derivative()
+	roots, err := deriv.distinctRealRoots()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, root := range roots {
+		x := real(root.Value)
+
+		// First derivative of order m + 1
+		d := deriv
+		for i := 0; i < root.Multiplicity; i++ {
+			d = d.Derivative()
+		}
+
+		kind := StationaryInflection
+		if (root.Multiplicity+1)%2 == 0 {
+			if d.At(x) > 0 {
+				kind = LocalMinimum
+			} else {
+				kind = LocalMaximum
+			}
+		}
+
+		points = append(points, CriticalPoint{X: x, Y: poly.At(x), Kind: kind})
+	}
+
+	return points, nil
+}
+
+// LocalMinima returns the local minima of the polynomial sorted by position
+func (poly *Polynomial) LocalMinima() ([]CriticalPoint, error) {
+	return poly.criticalPointsOfKind(LocalMinimum)
+}
+
+// LocalMaxima returns the local maxima of the polynomial sorted by position
+func (poly *Polynomial) LocalMaxima() ([]CriticalPoint, error) {
+	return poly.criticalPointsOfKind(LocalMaximum)
+}
+
+// InflectionPoints returns the points where the second derivative changes
+// sign, stationary inflection points included, sorted by position
+func (poly *Polynomial) InflectionPoints() ([]Point, error) {
+	if poly.IsZero() {
+		return nil, errors.New("infinitely many solutions")
+	}
+
+	points := []Point{}
+	if poly.Degree() < 3 {
+		return points, nil
+	}
+
+	roots, err := poly.Derivative().Derivative().distinctRealRoots()
+	if err != nil {
+		return nil, err
+	}
+
+	// The second derivative changes sign only at roots of odd multiplicity
+	for _, root := range roots {
+		if root.Multiplicity%2 == 1 {
+			x := real(root.Value)
+			points = append(points, Point{X: x, Y: poly.At(x)})
+		}
+	}
+
+	return points, nil
+}
+
+// GlobalExtremaOn returns the points where the polynomial attains its
+// minimum and maximum on the closed interval [a, b]
+func (poly *Polynomial) GlobalExtremaOn(a float64, b float64) (Point, Point, error) {
+	if a > b {
+		return Point{}, Point{}, errors.New("invalid interval")
+	}
+
+	candidates := []float64{a, b}
+	if poly.Degree() > 0 {
+		critical, err := poly.CriticalPoints()
+		if err != nil {
+			return Point{}, Point{}, err
+		}
+		for _, c := range critical {
+			if c.X > a && c.X < b {
+				candidates = append(candidates, c.X)
+			}
+		}
+	}
+
+	min := Point{X: a, Y: math.Inf(1)}
+	max := Point{X: a, Y: math.Inf(-1)}
+	for _, x := range candidates {
+		y := poly.At(x)
+		if y < min.Y {
+			min = Point{X: x, Y: y}
+		}
+		if y > max.Y {
+			max = Point{X: x, Y: y}
+		}
+	}
+
+	return min, max, nil
+}
+
+func (poly *Polynomial) criticalPointsOfKind(kind CriticalPointKind) ([]CriticalPoint, error) {
+	points, err := poly.CriticalPoints()
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := []CriticalPoint{}
+	for _, p := range points {
+		if p.Kind == kind {
+			filtered = append(filtered, p)
+		}
+	}
+
+	return filtered, nil
+}
+
+// Returns the distinct real roots of the polynomial with their
+// multiplicities, sorted by value as returned by RootsWithMultiplicity
+func (poly *Polynomial) distinctRealRoots() ([]Root, error) {
+	roots, err := poly.RootsWithMultiplicity()
+	if err != nil {
+		return nil, err
+	}
+
+	realRoots := []Root{}
+	for _, root := range roots {
+		if imag(root.Value) == 0 {
+			realRoots = append(realRoots, root)
+		}
+	}
+
+	return realRoots, nil
+}
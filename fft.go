@@ -0,0 +1,196 @@
+package polynomials
+
+import (
+	"math"
+	"math/bits"
+	"math/cmplx"
+)
+
+// Polynomial multiplication with the fast Fourier transform
+// https://en.wikipedia.org/wiki/Multiplication_algorithm#Fourier_transform_methods
+// https://en.wikipedia.org/wiki/Cooley%E2%80%93Tukey_FFT_algorithm
+//
+// The product of two polynomials is the convolution of their coefficients,
+// which the FFT computes in O(n log n). Unlike the double loop, the FFT
+// spreads its rounding error evenly over all coefficients of the product.
+// The error of every coefficient is bounded by about
+// 12 log2(N) machEps ||a||_2 ||b||_2 for transforms of length N, which can
+// swamp coefficients that are much smaller than the others. The convolution
+// of the absolute values of the coefficients is computed alongside the
+// product, and every coefficient whose error bound exceeds EpsFFT relative
+// to it is recomputed as a direct sum. These are usually the coefficients
+// near the ends of the product, which are sums of few terms. Products of
+// factors whose coefficients range over many orders of magnitude, such as
+// products of many linear factors, would have most coefficients recomputed.
+// A sample of the coefficients is checked before the transform, and the
+// double loop is used instead if most of them are inaccurate.
+
+// Multiplies coefficient slices with the FFT. If checked, the coefficients
+// that are not accurate to EpsFFT are recomputed directly, or false is
+// returned without transforming if most of a sample of them would be.
+// Unchecked products are only accurate relative to the norms of the
+// factors.
+func fftMult(a []float64, b []float64, checked bool) ([]float64, bool) {
+	// Factor out powers of x, which would otherwise leave exact zeros in the
+	// product that the error test would recompute
+	za := trailingZeros(a)
+	zb := trailingZeros(b)
+	a = a[:len(a)-za]
+	b = b[:len(b)-zb]
+
+	m := len(a) + len(b) - 1
+	n := 1 << bits.Len(uint(m-1))
+	errBound := fftErrorBound(a, b, n)
+
+	if checked {
+		const samples = 16
+		inaccurate := 0
+		for s := 0; s < samples; s++ {
+			_, abs := convolutionAt(a, b, s*(m-1)/(samples-1))
+			if errBound > EpsFFT*abs {
+				inaccurate++
+			}
+		}
+		if 2*inaccurate > samples {
+			return nil, false
+		}
+	}
+
+	// Both real sequences are transformed at once as x + iy
+	pack := func(x []float64, y []float64, abs bool) []complex128 {
+		z := make([]complex128, n)
+		for i, v := range x {
+			if abs {
+				v = math.Abs(v)
+			}
+			z[i] = complex(v, 0)
+		}
+		for i, v := range y {
+			if abs {
+				v = math.Abs(v)
+			}
+			z[i] += complex(0, v)
+		}
+		return z
+	}
+
+	twiddles := fftTwiddles(n)
+	prod := pack(a, b, false)
+	bound := make([]complex128, n)
+	fft(prod, twiddles)
+	if checked {
+		bound = pack(a, b, true)
+		fft(bound, twiddles)
+	}
+
+	// Separate the transforms of x and y and multiply them. The inverse
+	// transform of prod + i bound then holds both convolutions.
+	conv := make([]complex128, n)
+	for k := 0; k < n; k++ {
+		j := (n - k) % n
+
+		xp := (prod[k] + cmplx.Conj(prod[j])) * 0.5
+		yp := (prod[k] - cmplx.Conj(prod[j])) * complex(0, -0.5)
+		xb := (bound[k] + cmplx.Conj(bound[j])) * 0.5
+		yb := (bound[k] - cmplx.Conj(bound[j])) * complex(0, -0.5)
+
+		conv[k] = xp*yp + complex(0, 1)*xb*yb
+	}
+
+	// Inverse transform as the conjugate of the transform of the conjugate
+	for k := range conv {
+		conv[k] = cmplx.Conj(conv[k])
+	}
+	fft(conv, twiddles)
+
+	coeffs := make([]float64, m+za+zb)
+	for k := 0; k < m; k++ {
+		coeffs[k] = real(conv[k]) / float64(n)
+		abs := -imag(conv[k]) / float64(n)
+		if checked && errBound > EpsFFT*abs {
+			coeffs[k], _ = convolutionAt(a, b, k)
+		}
+	}
+
+	return coeffs, true
+}
+
+// Bound on the error of every coefficient of the FFT product of a and b
+// with transforms of length n
+func fftErrorBound(a []float64, b []float64, n int) float64 {
+	normA := 0.0
+	for _, v := range a {
+		normA += v * v
+	}
+	normB := 0.0
+	for _, v := range b {
+		normB += v * v
+	}
+	return 12.0 * float64(bits.Len(uint(n))) * machEps * math.Sqrt(normA*normB)
+}
+
+// Coefficient k of the convolution of a and b as a direct sum, and the
+// same coefficient of the convolution of their absolute values
+func convolutionAt(a []float64, b []float64, k int) (float64, float64) {
+	lo := k - len(b) + 1
+	if lo < 0 {
+		lo = 0
+	}
+	hi := k
+	if hi > len(a)-1 {
+		hi = len(a) - 1
+	}
+
+	sum, abs := 0.0, 0.0
+	for i := lo; i <= hi; i++ {
+		sum += a[i] * b[k-i]
+		abs += math.Abs(a[i] * b[k-i])
+	}
+	return sum, abs
+}
+
+// In-place iterative radix-2 FFT, len(z) must be a power of two
+func fft(z []complex128, twiddles []complex128) {
+	n := len(z)
+	shift := 64 - bits.Len(uint(n-1))
+
+	// Bit reversal permutation
+	for i := 0; i < n; i++ {
+		j := int(bits.Reverse64(uint64(i)) >> shift)
+		if i < j {
+			z[i], z[j] = z[j], z[i]
+		}
+	}
+
+	for size := 2; size <= n; size *= 2 {
+		half := size / 2
+		step := n / size
+		for start := 0; start < n; start += size {
+			for k := 0; k < half; k++ {
+				t := twiddles[k*step] * z[start+k+half]
+				z[start+k+half] = z[start+k] - t
+				z[start+k] += t
+			}
+		}
+	}
+}
+
+// Roots of unity exp(-2 pi i k / n) for k < n/2. Each one is computed
+// directly, since computing them as powers would accumulate rounding errors.
+func fftTwiddles(n int) []complex128 {
+	twiddles := make([]complex128, n/2)
+	for k := range twiddles {
+		s, c := math.Sincos(-2.0 * math.Pi * float64(k) / float64(n))
+		twiddles[k] = complex(c, s)
+	}
+	return twiddles
+}
+
+// Number of zero coefficients at the end of a leading-first coefficient slice
+func trailingZeros(coeffs []float64) int {
+	n := 0
+	for n < len(coeffs)-1 && coeffs[len(coeffs)-1-n] == 0 {
+		n++
+	}
+	return n
+}
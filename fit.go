@@ -0,0 +1,588 @@
+package polynomials
+
+import (
+	"errors"
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Weighted least-squares polynomial fitting
+// https://en.wikipedia.org/wiki/Polynomial_regression
+// https://en.wikipedia.org/wiki/Linear_least_squares#Weighted_linear_least_squares
+//
+// The points are first mapped onto [-1, 1] by t = (x - c) / h, since the
+// columns of a Vandermonde matrix in x are nearly parallel when the points
+// lie far from the origin or far apart. The fit minimizes
+// sum w_i (y_i - q(t_i))^2, which is solved with the QR decomposition of the
+// design matrix with rows sqrt(w_i) (t_i^d, ..., t_i, 1). The normal
+// equations would square its condition number. Finally p(x) = q((x - c) / h).
+//
+// Equality constraints are linear in the coefficients and are eliminated
+// before solving. A shape on an interval is the infinite set of linear
+// inequalities p(x) >= 0 or +-p'(x) >= 0 for all x in it. It is enforced on
+// a grid of points first, then the minimum of the constrained function on
+// the interval is found from its critical points and added to the grid until
+// the shape holds up to EpsFitShape relative to sum |c_k| max(1, |t|)^k.
+
+// Options of Fit
+type FitOptions struct {
+	Weights     []float64       // weights of the points, all ones if nil
+	Constraints []FitConstraint // equality constraints on the values and derivatives
+	Shape       FitShape        // shape of the polynomial on ShapeInterval
+	// Interval on which Shape is enforced. Defaults to the range of xs if
+	// it is empty or a single point. The ends must be finite.
+	ShapeInterval Interval
+}
+
+// A FitConstraint fixes the value of the given derivative of the fitted
+// polynomial at X, p^(Derivative)(X) = Value. The zero constant term is
+// FitConstraint{X: 0, Value: 0}.
+type FitConstraint struct {
+	X          float64
+	Derivative int
+	Value      float64
+}
+
+type FitShape int
+
+const (
+	AnyShape    FitShape = iota
+	NonNegative          // p(x) >= 0
+	Increasing           // p'(x) >= 0
+	Decreasing           // p'(x) <= 0
+)
+
+// Diagnostics of a least-squares fit
+type FitDiagnostics struct {
+	Residuals []float64 // ys[i] - p(xs[i])
+	RSquared  float64   // weighted coefficient of determination
+	// Covariance of the leading-first coefficients. Nil if the constraints
+	// leave as many coefficients free as there are points of positive
+	// weight, or with a shape.
+	Covariance      *mat.SymDense
+	ConditionNumber float64 // 2-norm condition number of the weighted design matrix in t
+}
+
+// Fit returns the polynomial of at most the given degree that fits the
+// points (xs[i], ys[i]) best in the weighted least-squares sense, and the
+// diagnostics of the fit. opts may be nil.
+func Fit(xs []float64, ys []float64, degree int, opts *FitOptions) (*Polynomial, *FitDiagnostics, error) {
+	if opts == nil {
+		opts = &FitOptions{}
+	}
+	if len(xs) != len(ys) {
+		return nil, nil, errors.New("xs and ys must be of the same length")
+	}
+	if degree < 0 {
+		return nil, nil, errors.New("degree must not be negative")
+	}
+
+	n := len(xs)
+	m := degree + 1
+	if n+len(opts.Constraints) < m || len(opts.Constraints) > m {
+		return nil, nil, errors.New("not enough points for the degree")
+	}
+
+	weights := make([]float64, n)
+	for i := range weights {
+		weights[i] = 1
+	}
+	if opts.Weights != nil {
+		if len(opts.Weights) != n {
+			return nil, nil, errors.New("xs and weights must be of the same length")
+		}
+		for i, w := range opts.Weights {
+			if w < 0 || math.IsNaN(w) {
+				return nil, nil, errors.New("weights must not be negative")
+			}
+			weights[i] = w
+		}
+	}
+
+	c, h := fitScaling(xs)
+
+	design := mat.NewDense(n, m, nil)
+	rhs := mat.NewVecDense(n, nil)
+	for i, x := range xs {
+		sw := math.Sqrt(weights[i])
+		row := fitRow(m, (x-c)/h, 0)
+		for j := range row {
+			design.Set(i, j, sw*row[j])
+		}
+		rhs.SetVec(i, sw*ys[i])
+	}
+
+	// Equality constraints in t, where d/dx = 1/h d/dt
+	var eq *mat.Dense
+	eqVals := make([]float64, len(opts.Constraints))
+	if len(opts.Constraints) > 0 {
+		eq = mat.NewDense(len(opts.Constraints), m, nil)
+		for i, con := range opts.Constraints {
+			if con.Derivative < 0 {
+				return nil, nil, errors.New("derivative order of a constraint must not be negative")
+			}
+			eq.SetRow(i, fitRow(m, (con.X-c)/h, con.Derivative))
+			eqVals[i] = con.Value * math.Pow(h, float64(con.Derivative))
+		}
+	}
+
+	// The shape is enforced at a grid of points, which is refined at the
+	// minima of the constrained function until it holds on the interval
+	ta, tb := 0.0, 0.0
+	grid := []float64{}
+	if opts.Shape != AnyShape {
+		a, b := opts.ShapeInterval.A, opts.ShapeInterval.B
+		if math.IsInf(a, 0) || math.IsInf(b, 0) || math.IsNaN(a) || math.IsNaN(b) {
+			return nil, nil, errors.New("shape interval must be finite")
+		}
+		if !(a < b) {
+			a, b = c-h, c+h
+		}
+		ta, tb = (a-c)/h, (b-c)/h
+		k := 4 * m
+		for i := 0; i < k; i++ {
+			s := math.Cos(math.Pi * float64(i) / float64(k-1))
+			grid = append(grid, (ta+tb)/2+(tb-ta)/2*s)
+		}
+	}
+
+	var scaled []float64
+	var basis *mat.Dense
+	for refinement := 0; ; refinement++ {
+		var ineq *mat.Dense
+		if len(grid) > 0 {
+			ineq = mat.NewDense(len(grid), m, nil)
+			for i, t := range grid {
+				row := fitRow(m, t, 0)
+				if opts.Shape != NonNegative {
+					row = fitRow(m, t, 1)
+				}
+				if opts.Shape == Decreasing {
+					for j := range row {
+						row[j] = -row[j]
+					}
+				}
+				ineq.SetRow(i, row)
+			}
+		}
+
+		var err error
+		scaled, basis, err = constrainedLeastSquares(design, rhs, eq, eqVals, ineq)
+		if err != nil {
+			return nil, nil, err
+		}
+		if opts.Shape == AnyShape {
+			break
+		}
+
+		// Minimum of the constrained function in t
+		q := CreatePolynomial(scaled...)
+		bound := 0.0
+		tMax := math.Max(1, math.Max(math.Abs(ta), math.Abs(tb)))
+		for _, coeff := range scaled {
+			bound = bound*tMax + math.Abs(coeff)
+		}
+		switch opts.Shape {
+		case Increasing:
+			q = q.Derivative()
+		case Decreasing:
+			q = q.Derivative().ScalarMult(-1)
+		}
+		min, _, err := q.GlobalExtremaOn(ta, tb)
+		if err != nil {
+			return nil, nil, err
+		}
+		if min.Y >= -EpsFitShape*bound {
+			break
+		}
+		if refinement == MaxFitRefinements {
+			return nil, nil, errors.New("shape constraint could not be enforced within MaxFitRefinements refinements")
+		}
+		grid = append(grid, min.X)
+	}
+
+	diag := &FitDiagnostics{
+		Residuals:       make([]float64, n),
+		ConditionNumber: mat.Cond(design, 2),
+	}
+
+	// Residuals and R^2 with the weighted mean of ys
+	sumW, mean := 0.0, 0.0
+	for i, y := range ys {
+		sumW += weights[i]
+		mean += weights[i] * y
+	}
+	if sumW > 0 {
+		mean /= sumW
+	}
+	ssRes, ssTot := 0.0, 0.0
+	for i, x := range xs {
+		t := (x - c) / h
+		fitted := 0.0
+		for _, b := range scaled {
+			fitted = fitted*t + b
+		}
+		diag.Residuals[i] = ys[i] - fitted
+		ssRes += weights[i] * diag.Residuals[i] * diag.Residuals[i]
+		ssTot += weights[i] * (ys[i] - mean) * (ys[i] - mean)
+	}
+	diag.RSquared = 1
+	if ssTot > 0 {
+		diag.RSquared = 1 - ssRes/ssTot
+	}
+
+	// Points of zero weight do not count towards the degrees of freedom
+	weighted := 0
+	for _, w := range weights {
+		if w > 0 {
+			weighted++
+		}
+	}
+
+	// The coefficients of p are a linear map T of those of q, so their
+	// covariance is T sigma^2 B B^T with B from constrainedLeastSquares
+	free := m - len(opts.Constraints)
+	if opts.Shape == AnyShape && basis != nil && weighted > free {
+		transform := mat.NewDense(m, m, nil)
+		unit := make([]float64, m)
+		for j := 0; j < m; j++ {
+			unit[j] = 1
+			transform.SetCol(j, unscaleFit(unit, c, h))
+			unit[j] = 0
+		}
+
+		var tBasis mat.Dense
+		tBasis.Mul(transform, basis)
+		var cov mat.Dense
+		cov.Mul(&tBasis, tBasis.T())
+		cov.Scale(ssRes/float64(weighted-free), &cov)
+
+		diag.Covariance = mat.NewSymDense(m, nil)
+		for i := 0; i < m; i++ {
+			for j := i; j < m; j++ {
+				diag.Covariance.SetSym(i, j, cov.At(i, j))
+			}
+		}
+	}
+
+	return CreatePolynomial(unscaleFit(scaled, c, h)...), diag, nil
+}
+
+// Row of the k-th derivative of (t^(m-1), ..., t, 1) at t
+func fitRow(m int, t float64, k int) []float64 {
+	row := make([]float64, m)
+	for j := range row {
+		e := m - 1 - j
+		if e < k {
+			continue
+		}
+		factor := 1.0
+		for i := 0; i < k; i++ {
+			factor *= float64(e - i)
+		}
+		row[j] = factor * math.Pow(t, float64(e-k))
+	}
+	return row
+}
+
+// Minimizes ||A b - y|| subject to E b = v and G b >= 0, where eq and ineq
+// may be nil. The equality constraints are eliminated with the QR
+// decomposition of E^T, b = b0 + Z z with E Z = 0. The remaining least
+// distance problem with inequality constraints is solved with nonnegative
+// least squares, see Lawson & Hanson, Solving Least Squares Problems,
+// chapter 23. Returns b and the matrix B with b = B z + b0 over the free
+// parameters z of the unconstrained problem, B B^T being the unscaled
+// covariance, or nil if no parameters are free.
+func constrainedLeastSquares(a *mat.Dense, y *mat.VecDense, eq *mat.Dense, eqVals []float64, ineq *mat.Dense) ([]float64, *mat.Dense, error) {
+	n, m := a.Dims()
+
+	b0 := mat.NewVecDense(m, nil)
+	var null mat.Matrix
+	free := m
+	if eq == nil {
+		identity := mat.NewDiagDense(m, nil)
+		for i := 0; i < m; i++ {
+			identity.SetDiag(i, 1)
+		}
+		null = identity
+	} else {
+		p, _ := eq.Dims()
+		free = m - p
+
+		var qr mat.QR
+		qr.Factorize(eq.T())
+		var q, r mat.Dense
+		qr.QTo(&q)
+		qr.RTo(&r)
+		if !fullRank(&r, p) {
+			return nil, nil, errors.New("constraints are inconsistent or redundant")
+		}
+
+		// b0 = Q1 R1^-T v
+		w := make([]float64, p)
+		for i := 0; i < p; i++ {
+			s := eqVals[i]
+			for k := 0; k < i; k++ {
+				s -= r.At(k, i) * w[k]
+			}
+			w[i] = s / r.At(i, i)
+		}
+		b0.MulVec(q.Slice(0, m, 0, p), mat.NewVecDense(p, w))
+
+		if free == 0 {
+			return b0.RawVector().Data, nil, nil
+		}
+		null = q.Slice(0, m, p, m)
+	}
+
+	// Least squares in z for A Z z = y - A b0
+	var az mat.Dense
+	az.Mul(a, null)
+	var yz mat.VecDense
+	yz.MulVec(a, b0)
+	yz.SubVec(y, &yz)
+
+	if n < free {
+		return nil, nil, errors.New("not enough points for the degree")
+	}
+	var qr mat.QR
+	qr.Factorize(&az)
+	var r mat.Dense
+	qr.RTo(&r)
+	if !fullRank(&r, free) {
+		return nil, nil, errors.New("design matrix is rank deficient. Use a lower degree or more distinct points")
+	}
+	var z mat.VecDense
+	if err := qr.SolveVecTo(&z, false, &yz); err != nil {
+		return nil, nil, err
+	}
+
+	var rInv mat.Dense
+	if err := rInv.Inverse(r.Slice(0, free, 0, free)); err != nil {
+		return nil, nil, err
+	}
+
+	if ineq != nil {
+		// With u = R z - f1, where f1 = R z for the unconstrained solution,
+		// minimize ||u|| subject to G Z R^-1 (u + f1) >= -G b0
+		var f1 mat.VecDense
+		f1.MulVec(r.Slice(0, free, 0, free), &z)
+
+		var g mat.Dense
+		g.Mul(ineq, null)
+		var g2 mat.Dense
+		g2.Mul(&g, &rInv)
+		var h2 mat.VecDense
+		h2.MulVec(&g2, &f1)
+		var gb0 mat.VecDense
+		gb0.MulVec(ineq, b0)
+		h2.AddVec(&h2, &gb0)
+		h2.ScaleVec(-1, &h2)
+
+		u, err := leastDistance(&g2, &h2)
+		if err != nil {
+			return nil, nil, err
+		}
+		u.AddVec(u, &f1)
+		z.MulVec(&rInv, u)
+	}
+
+	var b mat.VecDense
+	b.MulVec(null, &z)
+	b.AddVec(&b, b0)
+
+	var basis mat.Dense
+	basis.Mul(null, &rInv)
+
+	return b.RawVector().Data, &basis, nil
+}
+
+// Minimizes ||u|| subject to G u >= h as the nonnegative least-squares
+// problem min ||E w - f|| with E = [G^T; h^T] and f = (0, ..., 0, 1). The
+// last residual is -1 / (1 + ||u||^2), and vanishes if the constraints
+// cannot be satisfied.
+func leastDistance(g *mat.Dense, h *mat.VecDense) (*mat.VecDense, error) {
+	k, free := g.Dims()
+
+	// The problem is scaled so that ||u|| does not depend on the size of h
+	scale := mat.Norm(h, math.Inf(1))
+	if scale == 0 {
+		return mat.NewVecDense(free, nil), nil
+	}
+
+	e := mat.NewDense(free+1, k, nil)
+	for i := 0; i < k; i++ {
+		for j := 0; j < free; j++ {
+			e.Set(j, i, g.At(i, j))
+		}
+		e.Set(free, i, h.AtVec(i)/scale)
+	}
+	f := mat.NewVecDense(free+1, nil)
+	f.SetVec(free, 1)
+
+	w, err := nonnegativeLeastSquares(e, f)
+	if err != nil {
+		return nil, err
+	}
+	var res mat.VecDense
+	res.MulVec(e, w)
+	res.SubVec(&res, f)
+
+	if math.Abs(res.AtVec(free)) <= 100*float64(free+1)*machEps {
+		return nil, errors.New("constraints cannot be satisfied")
+	}
+
+	u := mat.NewVecDense(free, nil)
+	for j := 0; j < free; j++ {
+		u.SetVec(j, -scale*res.AtVec(j)/res.AtVec(free))
+	}
+	return u, nil
+}
+
+// Lawson-Hanson active set algorithm for min ||E w - f|| subject to w >= 0
+// https://en.wikipedia.org/wiki/Non-negative_least_squares
+// Returns an error instead of an unconverged w if the least-squares problem
+// on the passive columns cannot be solved or the iteration limit is reached.
+func nonnegativeLeastSquares(e *mat.Dense, f *mat.VecDense) (*mat.VecDense, error) {
+	rows, cols := e.Dims()
+	w := mat.NewVecDense(cols, nil)
+	passive := make([]bool, cols)
+	tol := 10 * machEps * mat.Norm(e, 1) * float64(rows+cols)
+
+	// Least squares on the passive columns only
+	solvePassive := func() (*mat.VecDense, bool) {
+		idx := []int{}
+		for j, p := range passive {
+			if p {
+				idx = append(idx, j)
+			}
+		}
+		if len(idx) > rows {
+			return nil, false
+		}
+		if len(idx) == 0 {
+			return mat.NewVecDense(cols, nil), true
+		}
+		sub := mat.NewDense(rows, len(idx), nil)
+		for c, j := range idx {
+			for i := 0; i < rows; i++ {
+				sub.Set(i, c, e.At(i, j))
+			}
+		}
+		var qr mat.QR
+		qr.Factorize(sub)
+		var x mat.VecDense
+		if err := qr.SolveVecTo(&x, false, f); err != nil {
+			return nil, false
+		}
+		s := mat.NewVecDense(cols, nil)
+		for c, j := range idx {
+			s.SetVec(j, x.AtVec(c))
+		}
+		return s, true
+	}
+
+	for iter := 0; ; iter++ {
+		// Gradient E^T (f - E w)
+		var res, grad mat.VecDense
+		res.MulVec(e, w)
+		res.SubVec(f, &res)
+		grad.MulVec(e.T(), &res)
+
+		best := -1
+		for j := 0; j < cols; j++ {
+			if !passive[j] && grad.AtVec(j) > tol && (best < 0 || grad.AtVec(j) > grad.AtVec(best)) {
+				best = j
+			}
+		}
+		if best < 0 {
+			return w, nil
+		}
+		if iter >= 3*cols {
+			return nil, errors.New("nonnegative least squares didn't converge before max number of iterations was reached")
+		}
+		passive[best] = true
+
+		for {
+			s, ok := solvePassive()
+			if !ok {
+				return nil, errors.New("nonnegative least squares failed, the passive columns are rank deficient")
+			}
+
+			feasible := true
+			alpha := 1.0
+			for j := 0; j < cols; j++ {
+				if passive[j] && s.AtVec(j) <= 0 {
+					feasible = false
+					alpha = math.Min(alpha, w.AtVec(j)/(w.AtVec(j)-s.AtVec(j)))
+				}
+			}
+			if feasible {
+				w = s
+				break
+			}
+
+			// Move towards s until a coefficient reaches zero
+			for j := 0; j < cols; j++ {
+				w.SetVec(j, w.AtVec(j)+alpha*(s.AtVec(j)-w.AtVec(j)))
+				if passive[j] && w.AtVec(j) <= tol {
+					passive[j] = false
+					w.SetVec(j, 0)
+				}
+			}
+		}
+	}
+}
+
+// Tests whether the diagonal of the triangular factor r is nonzero
+// relative to its largest entry
+func fullRank(r *mat.Dense, n int) bool {
+	rows, _ := r.Dims()
+	maxDiag := 0.0
+	for j := 0; j < n; j++ {
+		maxDiag = math.Max(maxDiag, math.Abs(r.At(j, j)))
+	}
+	for j := 0; j < n; j++ {
+		if math.Abs(r.At(j, j)) <= float64(rows)*machEps*maxDiag {
+			return false
+		}
+	}
+	return true
+}
+
+// Center and half width of the points
+func fitScaling(xs []float64) (float64, float64) {
+	if len(xs) == 0 {
+		return 0, 1
+	}
+
+	lo, hi := xs[0], xs[0]
+	for _, x := range xs {
+		lo = math.Min(lo, x)
+		hi = math.Max(hi, x)
+	}
+
+	c := (lo + hi) / 2
+	h := (hi - lo) / 2
+	if h == 0 {
+		h = 1
+	}
+	return c, h
+}
+
+// Leading-first coefficients of q((x - c) / h), with as many coefficients
+// as q
+func unscaleFit(q []float64, c float64, h float64) []float64 {
+	coeffs := []float64{q[0]}
+	for _, b := range q[1:] {
+		// coeffs = coeffs * (x - c) / h + b
+		coeffs = append(coeffs, 0)
+		for k := len(coeffs) - 1; k > 0; k-- {
+			coeffs[k] = (coeffs[k] - c*coeffs[k-1]) / h
+		}
+		coeffs[0] /= h
+		coeffs[len(coeffs)-1] += b
+	}
+	return coeffs
+}
@@ -0,0 +1,157 @@
+package polynomials
+
+import (
+	"math"
+)
+
+// Greatest common divisor and squarefree factorization
+// https://en.wikipedia.org/wiki/Polynomial_greatest_common_divisor
+// https://en.wikipedia.org/wiki/Square-free_polynomial
+//
+// Remainders computed in floating point are never exactly zero. Both
+// operands are scaled to unit max norm before each division and leading
+// remainder coefficients smaller than EpsGCD are treated as zero. Lower
+// coefficients are kept as they are, since a polynomial can have small
+// lower coefficients next to much larger leading ones.
+//
+// EpsGCD is far above the rounding error, so distinct roots closer than
+// about sqrt(EpsGCD) can show up as a common factor of p and p'. Such a
+// factor does not divide p, and the squarefree part and factorization only
+// divide by a GCD that leaves a remainder within the rounding error.
+
+// GCD returns the monic greatest common divisor of two polynomials
+func GCD(poly1 *Polynomial, poly2 *Polynomial) *Polynomial {
+	if poly1 == nil || poly2 == nil {
+		panic("received nil *Polynomial")
+	}
+
+	a := poly1.normalized()
+	b := poly2.normalized()
+
+	if a.IsZero() && b.IsZero() {
+		panic("GCD of two zero polynomials is undefined")
+	}
+
+	if len(a.coeffs) < len(b.coeffs) {
+		a, b = b, a
+	}
+
+	for !b.IsZero() {
+		_, r := a.EuclideanDiv(b)
+		a, b = b, r.trimmed(EpsGCD).normalized()
+	}
+
+	a.MakeMonic()
+	return a
+}
+
+// SquarefreePart returns the polynomial divided by GCD(p, p').
+// It has the same roots as the polynomial, each with multiplicity one.
+func (poly *Polynomial) SquarefreePart() *Polynomial {
+	if poly.Degree() < 1 {
+		return CreatePolynomial(poly.coeffs...)
+	}
+
+	g := GCD(poly, poly.Derivative())
+	if g.Degree() == 0 {
+		return CreatePolynomial(poly.coeffs...)
+	}
+
+	q, ok := poly.exactDiv(g)
+	if !ok {
+		return CreatePolynomial(poly.coeffs...)
+	}
+	return q
+}
+
+// SquarefreeFactorization computes the squarefree factorization of the
+// polynomial with Yun's algorithm. Element i of the returned slice is the
+// monic product of all factors of multiplicity i+1, so that
+//
+//	p = LeadingCoeff() * factors[0] * factors[1]^2 * factors[2]^3 ...
+//
+// https://en.wikipedia.org/wiki/Square-free_polynomial#Yun's_algorithm
+func (poly *Polynomial) SquarefreeFactorization() []*Polynomial {
+	factors := []*Polynomial{}
+	if poly.Degree() < 1 {
+		return factors
+	}
+
+	deriv := poly.Derivative()
+	a := GCD(poly, deriv)
+
+	b, ok := poly.exactDiv(a)
+	if !ok {
+		// Close roots, not a repeated factor
+		a = CreatePolynomial(1)
+		b = CreatePolynomial(poly.coeffs...)
+	}
+	c, _ := deriv.EuclideanDiv(a)
+	d := c.Sub(b.Derivative()).trimmed(EpsGCD * b.maxNorm())
+
+	for b.Degree() > 0 {
+		a = GCD(b, d)
+		factors = append(factors, a)
+
+		b, _ = b.EuclideanDiv(a)
+		c, _ = d.EuclideanDiv(a)
+		d = c.Sub(b.Derivative()).trimmed(EpsGCD * b.maxNorm())
+	}
+
+	// Normalize the factors to monic form
+	for _, f := range factors {
+		if !f.IsZero() {
+			f.MakeMonic()
+		}
+	}
+
+	return factors
+}
+
+// Divides by g and tests whether the remainder is within the rounding
+// error of the division, i.e. whether g is a factor of the polynomial
+func (poly *Polynomial) exactDiv(g *Polynomial) (*Polynomial, bool) {
+	q, r := poly.EuclideanDiv(g)
+
+	absG := make([]float64, len(g.coeffs))
+	for i, c := range g.coeffs {
+		absG[i] = math.Abs(c)
+	}
+	absQ := make([]float64, len(q.coeffs))
+	for i, c := range q.coeffs {
+		absQ[i] = math.Abs(c)
+	}
+	bound := math.Max(maxAbs(schoolbookConvolve(absG, absQ)), poly.maxNorm())
+
+	return q, r.maxNorm() <= 16*float64(len(poly.coeffs))*machEps*bound
+}
+
+// Returns the largest absolute value of the coefficients
+func (poly *Polynomial) maxNorm() float64 {
+	m := 0.0
+	for _, c := range poly.coeffs {
+		if math.Abs(c) > m {
+			m = math.Abs(c)
+		}
+	}
+	return m
+}
+
+// Returns a copy of the polynomial scaled to unit max norm
+func (poly *Polynomial) normalized() *Polynomial {
+	m := poly.maxNorm()
+	if m == 0 {
+		return CreatePolynomial()
+	}
+	return poly.ScalarMult(1.0 / m)
+}
+
+// Returns a copy of the polynomial with leading coefficients of absolute
+// value at most tol removed
+func (poly *Polynomial) trimmed(tol float64) *Polynomial {
+	i := 0
+	for i < len(poly.coeffs) && math.Abs(poly.coeffs[i]) <= tol {
+		i++
+	}
+	return CreatePolynomial(poly.coeffs[i:]...)
+}
@@ -0,0 +1,121 @@
+package polynomials
+
+import (
+	"math"
+)
+
+// Sign charts and polynomial inequalities
+// https://en.wikipedia.org/wiki/Multiplicity_(mathematics)#Behavior_of_a_polynomial_function_near_a_multiple_root
+//
+// The sign of the polynomial is constant between consecutive distinct real
+// roots. It equals the sign of the leading coefficient to the right of the
+// largest root and flips when passing a root of odd multiplicity, so double
+// roots touch zero without changing the sign. The signs are found from the
+// multiplicities alone and the polynomial is never evaluated between roots.
+
+type InequalityOp int
+
+const (
+	Greater        InequalityOp = iota // p(x) > 0
+	GreaterOrEqual                     // p(x) >= 0
+	Less                               // p(x) < 0
+	LessOrEqual                        // p(x) <= 0
+)
+
+// A SignChartEntry holds the sign of the polynomial on an interval,
+// -1, 0 or 1
+type SignChartEntry struct {
+	Interval Interval
+	Sign     int
+}
+
+// SignChart returns the sign of the polynomial on the whole real line as a
+// sequence of open intervals between the distinct real roots, each followed
+// by the root itself as a closed interval of sign zero
+func (poly *Polynomial) SignChart() ([]SignChartEntry, error) {
+	whole := Interval{A: math.Inf(-1), B: math.Inf(1), LeftOpen: true, RightOpen: true}
+	if poly.IsZero() {
+		return []SignChartEntry{{Interval: whole, Sign: 0}}, nil
+	}
+
+	roots := []Root{}
+	if poly.Degree() > 0 {
+		var err error
+		roots, err = poly.distinctRealRoots()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Sign to the left of the smallest root
+	sign := 1
+	if poly.LeadingCoeff() < 0 {
+		sign = -1
+	}
+	for _, root := range roots {
+		if root.Multiplicity%2 == 1 {
+			sign = -sign
+		}
+	}
+
+	chart := []SignChartEntry{}
+	left := math.Inf(-1)
+	for _, root := range roots {
+		x := real(root.Value)
+		chart = append(chart, SignChartEntry{Interval: Interval{A: left, B: x, LeftOpen: true, RightOpen: true}, Sign: sign})
+		chart = append(chart, SignChartEntry{Interval: Interval{A: x, B: x}, Sign: 0})
+
+		if root.Multiplicity%2 == 1 {
+			sign = -sign
+		}
+		left = x
+	}
+	chart = append(chart, SignChartEntry{Interval: Interval{A: left, B: math.Inf(1), LeftOpen: true, RightOpen: true}, Sign: sign})
+
+	return chart, nil
+}
+
+// SolveInequality returns the set of x for which the inequality p(x) op 0
+// holds, as a union of disjoint intervals in increasing order
+func (poly *Polynomial) SolveInequality(op InequalityOp) ([]Interval, error) {
+	chart, err := poly.SignChart()
+	if err != nil {
+		return nil, err
+	}
+
+	accept := func(sign int) bool {
+		switch op {
+		case Greater:
+			return sign > 0
+		case GreaterOrEqual:
+			return sign >= 0
+		case Less:
+			return sign < 0
+		case LessOrEqual:
+			return sign <= 0
+		}
+		return false
+	}
+
+	// Entries of the chart are adjacent, so consecutive accepted entries
+	// merge into one interval
+	intervals := []Interval{}
+	merging := false
+	for _, entry := range chart {
+		if !accept(entry.Sign) {
+			merging = false
+			continue
+		}
+
+		if merging {
+			last := &intervals[len(intervals)-1]
+			last.B = entry.Interval.B
+			last.RightOpen = entry.Interval.RightOpen
+		} else {
+			intervals = append(intervals, entry.Interval)
+			merging = true
+		}
+	}
+
+	return intervals, nil
+}
@@ -0,0 +1,56 @@
+package polynomials
+
+import (
+	"math"
+)
+
+// Integration of polynomials
+// https://en.wikipedia.org/wiki/Antiderivative
+// https://en.wikipedia.org/wiki/Kahan_summation_algorithm
+
+// Integral returns the antiderivative of the polynomial whose constant
+// term is the given constant
+func (poly *Polynomial) Integral(constant float64) *Polynomial {
+	n := len(poly.coeffs)
+	integralCoeffs := make([]float64, n+1)
+	for i := 0; i < n; i++ {
+		integralCoeffs[i] = poly.coeffs[i] / float64(n-i)
+	}
+	integralCoeffs[n] = constant
+
+	return CreatePolynomial(integralCoeffs...)
+}
+
+// DefiniteIntegral returns the integral of the polynomial from a to b.
+//
+// The term of degree k integrates to c_k (b^(k+1) - a^(k+1)) / (k+1), which
+// is computed as c_k (b - a) (b^k + b^(k-1) a + ... + a^k) / (k+1) so that
+// no cancellation occurs when a and b are close to each other. The terms
+// are added with Neumaier's compensated summation.
+func (poly *Polynomial) DefiniteIntegral(a float64, b float64) float64 {
+	n := len(poly.coeffs)
+
+	sum := 0.0
+	compensation := 0.0
+
+	// h = b^k + b^(k-1) a + ... + a^k
+	h := 1.0
+	ak := 1.0
+	for k := 0; k < n; k++ {
+		if k > 0 {
+			ak *= a
+			h = b*h + ak
+		}
+		term := poly.coeffs[n-1-k] / float64(k+1) * h
+
+		t := sum + term
+		if math.Abs(sum) >= math.Abs(term) {
+			compensation += (sum - t) + term
+		} else {
+			compensation += (term - t) + sum
+		}
+		sum = t
+	}
+
+	return (b - a) * (sum + compensation)
+}
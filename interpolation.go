@@ -0,0 +1,222 @@
+package polynomials
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// Polynomial interpolation
+// https://en.wikipedia.org/wiki/Lagrange_polynomial
+// https://en.wikipedia.org/wiki/Newton_polynomial
+// https://en.wikipedia.org/wiki/Hermite_interpolation
+//
+// The interpolating polynomial of n points is unique, so the constructors
+// differ only in rounding errors and cost. All of them take O(n^2). The
+// monomial coefficients of an interpolating polynomial can be much larger
+// than its values, in which case evaluating the result loses accuracy. The
+// barycentric form evaluates the interpolant from the samples directly and
+// is stable for points at which interpolation is well conditioned, such as
+// Chebyshev points.
+
+// InterpolateLagrange returns the polynomial of degree below len(xs) with
+// p(xs[i]) = ys[i] as a sum of Lagrange basis polynomials. The points must
+// be distinct.
+func InterpolateLagrange(xs []float64, ys []float64) (*Polynomial, error) {
+	if err := checkSamples(xs, ys); err != nil {
+		return nil, err
+	}
+	n := len(xs)
+
+	// m = prod (x - x_j)
+	m := []float64{1}
+	for _, x := range xs {
+		m = append(m, 0)
+		for k := len(m) - 1; k > 0; k-- {
+			m[k] -= x * m[k-1]
+		}
+	}
+
+	coeffs := make([]float64, n)
+	basis := make([]float64, n)
+	for i, xi := range xs {
+		// m / (x - x_i) by synthetic division
+		basis[0] = m[0]
+		for k := 1; k < n; k++ {
+			basis[k] = m[k] + xi*basis[k-1]
+		}
+
+		denom := 1.0
+		for j, xj := range xs {
+			if j != i {
+				denom *= xi - xj
+			}
+		}
+
+		w := ys[i] / denom
+		for k := range coeffs {
+			coeffs[k] += w * basis[k]
+		}
+	}
+
+	return CreatePolynomial(coeffs...), nil
+}
+
+// InterpolateNewton returns the polynomial of degree below len(xs) with
+// p(xs[i]) = ys[i] from the divided differences of the samples. The points
+// must be distinct.
+func InterpolateNewton(xs []float64, ys []float64) (*Polynomial, error) {
+	if err := checkSamples(xs, ys); err != nil {
+		return nil, err
+	}
+
+	// diffs[i] = f[x_0, ..., x_i]
+	diffs := make([]float64, len(ys))
+	copy(diffs, ys)
+	for k := 1; k < len(xs); k++ {
+		for i := len(xs) - 1; i >= k; i-- {
+			diffs[i] = (diffs[i] - diffs[i-1]) / (xs[i] - xs[i-k])
+		}
+	}
+
+	return CreatePolynomial(expandNewton(xs, diffs)...), nil
+}
+
+// InterpolateHermite returns the polynomial of degree below 2 len(xs) with
+// p(xs[i]) = ys[i] and p'(xs[i]) = dys[i]. The points must be distinct.
+func InterpolateHermite(xs []float64, ys []float64, dys []float64) (*Polynomial, error) {
+	if err := checkSamples(xs, ys); err != nil {
+		return nil, err
+	}
+	if len(dys) != len(xs) {
+		return nil, errors.New("xs and dys must be of the same length")
+	}
+
+	// Divided differences over the points repeated twice, where the first
+	// order differences of a repeated point are the derivatives
+	n := 2 * len(xs)
+	zs := make([]float64, n)
+	diffs := make([]float64, n)
+	for i := range xs {
+		zs[2*i], zs[2*i+1] = xs[i], xs[i]
+		diffs[2*i], diffs[2*i+1] = ys[i], ys[i]
+	}
+	for i := n - 1; i >= 1; i-- {
+		if i%2 == 1 {
+			diffs[i] = dys[i/2]
+		} else {
+			diffs[i] = (diffs[i] - diffs[i-1]) / (zs[i] - zs[i-1])
+		}
+	}
+	for k := 2; k < n; k++ {
+		for i := n - 1; i >= k; i-- {
+			diffs[i] = (diffs[i] - diffs[i-1]) / (zs[i] - zs[i-k])
+		}
+	}
+
+	return CreatePolynomial(expandNewton(zs, diffs)...), nil
+}
+
+// A Barycentric interpolant evaluates the polynomial through a set of
+// samples without computing its coefficients
+// https://en.wikipedia.org/wiki/Lagrange_polynomial#Barycentric_form
+type Barycentric struct {
+	xs      []float64
+	ys      []float64
+	weights []float64
+}
+
+// CreateBarycentric computes the barycentric weights of the points in
+// O(n^2). The points must be distinct. The differences of the points are
+// scaled by 4/(max - min), the inverse of the capacity of the interval,
+// which keeps the products from overflowing or underflowing for wide or
+// narrow intervals. The common factor cancels in At.
+func CreateBarycentric(xs []float64, ys []float64) (*Barycentric, error) {
+	if err := checkSamples(xs, ys); err != nil {
+		return nil, err
+	}
+
+	b := &Barycentric{
+		xs:      make([]float64, len(xs)),
+		ys:      make([]float64, len(ys)),
+		weights: make([]float64, len(xs)),
+	}
+	copy(b.xs, xs)
+	copy(b.ys, ys)
+
+	lo, hi := xs[0], xs[0]
+	for _, x := range xs {
+		lo = math.Min(lo, x)
+		hi = math.Max(hi, x)
+	}
+	scale := 1.0
+	if hi > lo {
+		scale = 4.0 / (hi - lo)
+	}
+
+	for i, xi := range xs {
+		w := 1.0
+		for j, xj := range xs {
+			if j != i {
+				w *= scale * (xi - xj)
+			}
+		}
+		b.weights[i] = 1.0 / w
+	}
+
+	return b, nil
+}
+
+// At returns the value of the interpolant at x in O(n)
+func (b *Barycentric) At(x float64) float64 {
+	if len(b.xs) == 0 {
+		return 0
+	}
+
+	num := 0.0
+	denom := 0.0
+	for i, xi := range b.xs {
+		if x == xi {
+			return b.ys[i]
+		}
+		t := b.weights[i] / (x - xi)
+		num += t * b.ys[i]
+		denom += t
+	}
+
+	return num / denom
+}
+
+// Coefficients of the Newton form sum diffs[i] prod_{j<i} (x - xs[j]),
+// leading-first
+func expandNewton(xs []float64, diffs []float64) []float64 {
+	coeffs := []float64{}
+	for i := len(diffs) - 1; i >= 0; i-- {
+		// coeffs = coeffs * (x - xs[i]) + diffs[i]
+		coeffs = append(coeffs, 0)
+		for k := len(coeffs) - 1; k > 0; k-- {
+			coeffs[k] -= xs[i] * coeffs[k-1]
+		}
+		coeffs[len(coeffs)-1] += diffs[i]
+	}
+	return coeffs
+}
+
+// Checks that there are as many values as points and the points are
+// distinct
+func checkSamples(xs []float64, ys []float64) error {
+	if len(xs) != len(ys) {
+		return errors.New("xs and ys must be of the same length")
+	}
+
+	sorted := make([]float64, len(xs))
+	copy(sorted, xs)
+	sort.Float64s(sorted)
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i] == sorted[i-1] {
+			return errors.New("xs must be distinct")
+		}
+	}
+
+	return nil
+}
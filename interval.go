@@ -1,17 +1,55 @@
 package polynomials
 
+import (
+	"fmt"
+	"math"
+)
 
 
 
 
-
+// An Interval between A and B. The ends are closed unless marked open,
+// and A and B may be infinite.
 type Interval struct {
-	A float64
-	B float64
+	A         float64
+	B         float64
+	LeftOpen  bool
+	RightOpen bool
 }
 
 
 
 func (i *Interval) Mid() float64 {
 	return (i.A + i.B) / 2.0
-}
\ No newline at end of file
+}
+
+// Contains tests whether x lies in the interval
+func (i *Interval) Contains(x float64) bool {
+	if x < i.A || x > i.B {
+		return false
+	}
+	if x == i.A && i.LeftOpen {
+		return false
+	}
+	if x == i.B && i.RightOpen {
+		return false
+	}
+	return true
+}
+
+// IsEmpty tests whether the interval contains no points
+func (i *Interval) IsEmpty() bool {
+	return i.A > i.B || (i.A == i.B && (i.LeftOpen || i.RightOpen))
+}
+
+// String returns the interval in the usual notation, e.g. (-Inf, 1]
+func (i Interval) String() string {
+	left, right := "[", "]"
+	if i.LeftOpen || math.IsInf(i.A, 0) {
+		left = "("
+	}
+	if i.RightOpen || math.IsInf(i.B, 0) {
+		right = ")"
+	}
+	return fmt.Sprintf("%s%v, %v%s", left, i.A, i.B, right)
+}
@@ -0,0 +1,723 @@
+package polynomials
+
+import (
+	"errors"
+	"math"
+)
+
+// Jenkins-Traub method for polynomials with real coefficients (RPOLY)
+// https://en.wikipedia.org/wiki/Jenkins–Traub_algorithm
+//
+// Port of the real arithmetic variant published as Algorithm 493 in
+// ACM Transactions on Mathematical Software (1975). Roots are found one
+// at a time, either as a single real root or as a pair of complex
+// conjugate roots extracted through a quadratic factor, and the
+// polynomial is deflated after each step.
+
+const machEps = 2.220446049250313e-16
+
+func (poly *Polynomial) JenkinsTraubRoots() ([]complex128, error) {
+	roots := []complex128{}
+
+	// Remove roots at the origin
+	coeffs := poly.coeffs
+	for len(coeffs) > 1 && coeffs[len(coeffs)-1] == 0.0 {
+		coeffs = coeffs[:len(coeffs)-1]
+		roots = append(roots, complex(0, 0))
+	}
+
+	n := len(coeffs) - 1
+	if n < 1 {
+		return roots, nil
+	}
+
+	s := newRpolyState(coeffs)
+
+	// Rotation of the shift by 94 degrees between attempts
+	cosr := math.Cos(94.0 * math.Pi / 180.0)
+	sinr := math.Sin(94.0 * math.Pi / 180.0)
+	xx := math.Sqrt(0.5)
+	yy := -xx
+
+	for s.n >= 1 {
+		if s.n == 1 {
+			roots = append(roots, complex(-s.p[1]/s.p[0], 0))
+			break
+		}
+		if s.n == 2 {
+			sr, si, lr, li := quadraticZeros(s.p[0], s.p[1], s.p[2])
+			roots = append(roots, complex(sr, si), complex(lr, li))
+			break
+		}
+
+		s.scale()
+		bnd := s.lowerRootBound()
+		s.noShift()
+
+		saved := make([]float64, s.n)
+		copy(saved, s.k[:s.n])
+
+		found := false
+		for jj := 1; jj <= JenkinsTraubMaxShifts; jj++ {
+			// The shift is a point of modulus bnd rotated by 94 degrees
+			// from the previous shift, together with its conjugate
+			xxx := cosr*xx - sinr*yy
+			yy = sinr*xx + cosr*yy
+			xx = xxx
+			sr := bnd * xx
+			u := -2.0 * sr
+
+			nz := s.fixedShift(20*jj, sr, bnd, u)
+			if nz != 0 {
+				roots = append(roots, complex(s.szr, s.szi))
+				if nz == 2 {
+					roots = append(roots, complex(s.lzr, s.lzi))
+				}
+				s.deflate(nz)
+				found = true
+				break
+			}
+
+			// Restore K before trying another shift
+			copy(s.k[:s.n], saved)
+		}
+
+		if !found {
+			return roots, errors.New("Jenkins-Traub method didn't converge before max number of shifts was reached! Result may be incomplete")
+		}
+	}
+
+	return roots, nil
+}
+
+// rpolyState holds the working polynomials and the scalars shared by the
+// three stages of the algorithm.
+type rpolyState struct {
+	p, qp, k, qk, svk []float64
+
+	n int
+
+	a, b, c, d, e, f, g, h float64
+	a1, a3, a7             float64
+	u, v                   float64
+
+	szr, szi, lzr, lzi float64
+}
+
+func newRpolyState(coeffs []float64) *rpolyState {
+	nn := len(coeffs)
+	s := &rpolyState{
+		p:   append([]float64{}, coeffs...),
+		qp:  make([]float64, nn),
+		k:   make([]float64, nn),
+		qk:  make([]float64, nn),
+		svk: make([]float64, nn),
+		n:   nn - 1,
+	}
+	return s
+}
+
+func (s *rpolyState) deflate(nz int) {
+	nn := s.n + 1 - nz
+	copy(s.p, s.qp[:nn])
+	s.n = nn - 1
+}
+
+// Scales the coefficients by a power of two if there are very large or
+// very small coefficients.
+func (s *rpolyState) scale() {
+	nn := s.n + 1
+	maxMod := 0.0
+	minMod := math.MaxFloat64
+	for i := 0; i < nn; i++ {
+		x := math.Abs(s.p[i])
+		if x > maxMod {
+			maxMod = x
+		}
+		if x != 0 && x < minMod {
+			minMod = x
+		}
+	}
+
+	sc := (math.SmallestNonzeroFloat64 / machEps) / minMod
+	if sc > 1.0 {
+		if math.MaxFloat64/sc < maxMod {
+			return
+		}
+	} else {
+		if maxMod < 10.0 {
+			return
+		}
+		if sc == 0 {
+			sc = math.SmallestNonzeroFloat64
+		}
+	}
+
+	l := int(math.Log2(sc) + 0.5)
+	factor := math.Pow(2.0, float64(l))
+	if factor != 1.0 {
+		for i := 0; i < nn; i++ {
+			s.p[i] *= factor
+		}
+	}
+}
+
+// Computes a lower bound on the moduli of the roots from the Cauchy
+// polynomial |a_n|x^n + ... + |a_1|x - |a_0|.
+func (s *rpolyState) lowerRootBound() float64 {
+	n := s.n
+	pt := make([]float64, n+1)
+	for i := 0; i <= n; i++ {
+		pt[i] = math.Abs(s.p[i])
+	}
+	pt[n] = -pt[n]
+
+	x := math.Exp((math.Log(-pt[n]) - math.Log(pt[0])) / float64(n))
+	if pt[n-1] != 0 {
+		// Use the Newton step at the origin if it is better
+		xm := -pt[n] / pt[n-1]
+		if xm < x {
+			x = xm
+		}
+	}
+
+	// Chop the interval (0, x) until ff <= 0
+	xm := x
+	ff := 0.0
+	for {
+		x = xm
+		xm = 0.1 * x
+		ff = pt[0]
+		for i := 1; i <= n; i++ {
+			ff = ff*xm + pt[i]
+		}
+		if ff <= 0 {
+			break
+		}
+	}
+
+	// Newton iteration until x converges to two decimal places
+	for {
+		ff = pt[0]
+		df := ff
+		for i := 1; i < n; i++ {
+			ff = x*ff + pt[i]
+			df = x*df + ff
+		}
+		ff = x*ff + pt[n]
+		dx := ff / df
+		x -= dx
+		if math.Abs(dx/x) <= 0.005 {
+			break
+		}
+	}
+
+	return x
+}
+
+// Stage one: computes the K polynomials without a shift.
+func (s *rpolyState) noShift() {
+	n := s.n
+	nm1 := n - 1
+	for i := 1; i < n; i++ {
+		s.k[i] = float64(n-i) * s.p[i] / float64(n)
+	}
+	s.k[0] = s.p[0]
+
+	aa := s.p[n]
+	bb := s.p[nm1]
+	zerok := s.k[nm1] == 0
+
+	for jj := 0; jj < 5; jj++ {
+		cc := s.k[nm1]
+		if zerok {
+			// Unscaled form of the recurrence
+			for j := nm1; j > 0; j-- {
+				s.k[j] = s.k[j-1]
+			}
+			s.k[0] = 0
+			zerok = s.k[nm1] == 0
+		} else {
+			// Scaled form of the recurrence if the value of K at 0 is non-zero
+			t := -aa / cc
+			for j := nm1; j > 0; j-- {
+				s.k[j] = t*s.k[j-1] + s.p[j]
+			}
+			s.k[0] = s.p[0]
+			zerok = math.Abs(s.k[nm1]) <= math.Abs(bb)*machEps*10.0
+		}
+	}
+}
+
+// Stage two: computes up to l2 fixed shift K polynomials, testing for
+// convergence in the linear or quadratic case. Starts one of the variable
+// shift iterations and returns the number of roots found.
+func (s *rpolyState) fixedShift(l2 int, sr float64, v float64, u float64) int {
+	n := s.n
+	betav := 0.25
+	betas := 0.25
+	oss := sr
+	ovv := v
+	var otv, ots float64
+
+	s.u = u
+	s.v = v
+
+	// Evaluate polynomial by synthetic division
+	s.a, s.b = quadSyntheticDiv(s.n+1, s.u, s.v, s.p, s.qp)
+	tFlag := s.calcSC()
+
+	for j := 0; j < l2; j++ {
+		// Calculate next K polynomial and estimate v
+		s.nextK(tFlag)
+		tFlag = s.calcSC()
+		ui, vi := s.newEstimate(tFlag)
+
+		vv := vi
+
+		// Estimate s
+		ss := 0.0
+		if s.k[n-1] != 0.0 {
+			ss = -s.p[n] / s.k[n-1]
+		}
+		tv := 1.0
+		ts := 1.0
+
+		if j != 0 && tFlag != 3 {
+			// Relative measures of convergence of the s and v sequences
+			if vv != 0.0 {
+				tv = math.Abs((vv - ovv) / vv)
+			}
+			if ss != 0.0 {
+				ts = math.Abs((ss - oss) / ss)
+			}
+
+			// If decreasing, multiply the two most recent convergence measures
+			tvv := 1.0
+			if tv < otv {
+				tvv = tv * otv
+			}
+			tss := 1.0
+			if ts < ots {
+				tss = ts * ots
+			}
+
+			vpass := tvv < betav
+			spass := tss < betas
+
+			if spass || vpass {
+				// At least one sequence has passed the convergence test.
+				// Store variables before iterating
+				copy(s.svk[:n], s.k[:n])
+				sv := ss
+
+				// Choose the iteration according to the fastest converging sequence
+				var vtry, stry bool
+				tryQuad := !(spass && (!vpass || tss < tvv))
+
+				for {
+					tryLinear := true
+					if tryQuad {
+						if nz := s.quadraticIteration(ui, vi); nz > 0 {
+							return nz
+						}
+
+						// Quadratic iteration has failed. Flag that it has
+						// been tried and decrease the convergence criterion
+						vtry = true
+						betav *= 0.25
+
+						// Try linear iteration if it has not been tried and
+						// the s sequence is converging
+						tryLinear = !stry && spass
+						if tryLinear {
+							copy(s.k[:n], s.svk[:n])
+						}
+					}
+
+					if tryLinear {
+						nz, nearDouble := s.realIteration(&sv)
+						if nz > 0 {
+							return nz
+						}
+
+						// Linear iteration has failed. Flag that it has been
+						// tried and decrease the convergence criterion
+						stry = true
+						betas *= 0.25
+
+						if nearDouble {
+							// An almost double real root, attempt quadratic iteration
+							ui = -(sv + sv)
+							vi = sv * sv
+							tryQuad = true
+							continue
+						}
+					}
+
+					// Restore variables
+					copy(s.k[:n], s.svk[:n])
+
+					// Try quadratic iteration if it has not been tried and
+					// the v sequence is converging
+					if vpass && !vtry {
+						tryQuad = true
+						continue
+					}
+					break
+				}
+
+				// Recompute qp and the scalars to continue the second stage
+				s.u = u
+				s.v = v
+				s.a, s.b = quadSyntheticDiv(s.n+1, s.u, s.v, s.p, s.qp)
+				tFlag = s.calcSC()
+			}
+		}
+
+		ovv = vv
+		oss = ss
+		otv = tv
+		ots = ts
+	}
+
+	return 0
+}
+
+// Stage three, quadratic case: variable shift K polynomial iteration for a
+// quadratic factor. Converges only if the roots are equimodular or nearly so.
+func (s *rpolyState) quadraticIteration(uu float64, vv float64) int {
+	n := s.n
+	nn := n + 1
+	s.u = uu
+	s.v = vv
+
+	var omp, relstp float64
+	triedCluster := false
+	j := 0
+
+	for {
+		s.szr, s.szi, s.lzr, s.lzi = quadraticZeros(1.0, s.u, s.v)
+
+		// Return if the roots of the quadratic are real and not close to
+		// multiple or nearly equal and of opposite sign
+		if math.Abs(math.Abs(s.szr)-math.Abs(s.lzr)) > 0.01*math.Abs(s.lzr) {
+			return 0
+		}
+
+		// Evaluate polynomial by quadratic synthetic division
+		s.a, s.b = quadSyntheticDiv(nn, s.u, s.v, s.p, s.qp)
+
+		mp := math.Abs(s.a-s.szr*s.b) + math.Abs(s.szi*s.b)
+
+		// Rigorous bound on the rounding error in evaluating p
+		zm := math.Sqrt(math.Abs(s.v))
+		ee := 2.0 * math.Abs(s.qp[0])
+		t := -s.szr * s.b
+		for i := 1; i < n; i++ {
+			ee = ee*zm + math.Abs(s.qp[i])
+		}
+		ee = ee*zm + math.Abs(s.a+t)
+		ee = (9.0*ee + 2.0*math.Abs(t) - 7.0*(math.Abs(s.a+t)+zm*math.Abs(s.b))) * machEps
+
+		// Converged if the value is less than 20 times this bound
+		if mp <= 20.0*ee {
+			return 2
+		}
+
+		j++
+		if j > 20 {
+			return 0
+		}
+
+		if j >= 2 && relstp <= 0.01 && mp >= omp && !triedCluster {
+			// A cluster appears to be stalling the convergence. Five fixed
+			// shift steps are taken with u, v close to the cluster
+			if relstp < machEps {
+				relstp = math.Sqrt(machEps)
+			} else {
+				relstp = math.Sqrt(relstp)
+			}
+			s.u -= s.u * relstp
+			s.v += s.v * relstp
+
+			s.a, s.b = quadSyntheticDiv(nn, s.u, s.v, s.p, s.qp)
+			for i := 0; i < 5; i++ {
+				s.nextK(s.calcSC())
+			}
+
+			triedCluster = true
+			j = 0
+		}
+		omp = mp
+
+		// Next K polynomial and new u and v
+		s.nextK(s.calcSC())
+		ui, vi := s.newEstimate(s.calcSC())
+
+		// If vi is zero the iteration is not converging
+		if vi == 0 {
+			return 0
+		}
+		relstp = math.Abs((vi - s.v) / vi)
+		s.u = ui
+		s.v = vi
+	}
+}
+
+// Stage three, linear case: variable shift H polynomial iteration for a
+// real root. Returns the number of roots found and whether a cluster of
+// roots near the real axis was encountered, in which case sss holds the
+// starting point for a quadratic iteration.
+func (s *rpolyState) realIteration(sss *float64) (int, bool) {
+	n := s.n
+	nn := n + 1
+	nm1 := n - 1
+	x := *sss
+
+	var omp, t float64
+	j := 0
+
+	for {
+		// Evaluate p at x
+		pv := s.p[0]
+		s.qp[0] = pv
+		for i := 1; i < nn; i++ {
+			pv = pv*x + s.p[i]
+			s.qp[i] = pv
+		}
+		mp := math.Abs(pv)
+
+		// Rigorous bound on the error in evaluating p
+		ms := math.Abs(x)
+		ee := 0.5 * math.Abs(s.qp[0])
+		for i := 1; i < nn; i++ {
+			ee = ee*ms + math.Abs(s.qp[i])
+		}
+
+		// Converged if the value is less than 20 times this bound
+		if mp <= 20.0*machEps*(2.0*ee-mp) {
+			s.szr = x
+			s.szi = 0
+			return 1, false
+		}
+
+		j++
+		if j > 10 {
+			return 0, false
+		}
+
+		if j >= 2 && math.Abs(t) <= 0.001*math.Abs(x-t) && mp > omp {
+			// A cluster of roots near the real axis has been encountered
+			*sss = x
+			return 0, true
+		}
+		omp = mp
+
+		// Compute t, the next polynomial and the new iterate
+		kv := s.k[0]
+		s.qk[0] = kv
+		for i := 1; i < n; i++ {
+			kv = kv*x + s.k[i]
+			s.qk[i] = kv
+		}
+
+		if math.Abs(kv) > math.Abs(s.k[nm1])*10.0*machEps {
+			// Scaled form of the recurrence if the value of K at x is non-zero
+			t = -pv / kv
+			s.k[0] = s.qp[0]
+			for i := 1; i < n; i++ {
+				s.k[i] = t*s.qk[i-1] + s.qp[i]
+			}
+		} else {
+			// Unscaled form
+			s.k[0] = 0.0
+			for i := 1; i < n; i++ {
+				s.k[i] = s.qk[i-1]
+			}
+		}
+
+		kv = s.k[0]
+		for i := 1; i < n; i++ {
+			kv = kv*x + s.k[i]
+		}
+		t = 0.0
+		if math.Abs(kv) > math.Abs(s.k[nm1])*10.0*machEps {
+			t = -pv / kv
+		}
+		x += t
+	}
+}
+
+// Calculates the scalar quantities used to compute the next K polynomial
+// and new estimates of the quadratic coefficients. The returned flag tells
+// how the calculations are normalized to avoid overflow:
+// 1 - divided by c, 2 - divided by d, 3 - the quadratic is almost a factor of K
+func (s *rpolyState) calcSC() int {
+	n := s.n
+
+	// Synthetic division of K by the quadratic 1, u, v
+	s.c, s.d = quadSyntheticDiv(n, s.u, s.v, s.k, s.qk)
+
+	if math.Abs(s.c) <= 100.0*machEps*math.Abs(s.k[n-1]) &&
+		math.Abs(s.d) <= 100.0*machEps*math.Abs(s.k[n-2]) {
+		return 3
+	}
+
+	s.h = s.v * s.b
+	if math.Abs(s.d) >= math.Abs(s.c) {
+		s.e = s.a / s.d
+		s.f = s.c / s.d
+		s.g = s.u * s.b
+		s.a3 = s.e*(s.g+s.a) + s.h*(s.b/s.d)
+		s.a1 = s.f*s.b - s.a
+		s.a7 = s.h + (s.f+s.u)*s.a
+		return 2
+	}
+
+	s.e = s.a / s.c
+	s.f = s.d / s.c
+	s.g = s.e * s.u
+	s.a3 = s.e*s.a + (s.g+s.h/s.c)*s.b
+	s.a1 = s.b - s.a*(s.d/s.c)
+	s.a7 = s.g*s.d + s.h*s.f + s.a
+	return 1
+}
+
+// Computes the next K polynomial using the scalars computed in calcSC.
+func (s *rpolyState) nextK(tFlag int) {
+	n := s.n
+
+	if tFlag == 3 {
+		// Unscaled form of the recurrence
+		s.k[0] = 0
+		s.k[1] = 0
+		for i := 2; i < n; i++ {
+			s.k[i] = s.qk[i-2]
+		}
+		return
+	}
+
+	temp := s.a
+	if tFlag == 1 {
+		temp = s.b
+	}
+
+	if math.Abs(s.a1) > 10.0*machEps*math.Abs(temp) {
+		// Scaled form of the recurrence
+		s.a7 /= s.a1
+		s.a3 /= s.a1
+		s.k[0] = s.qp[0]
+		s.k[1] = s.qp[1] - s.a7*s.qp[0]
+		for i := 2; i < n; i++ {
+			s.k[i] = s.a3*s.qk[i-2] - s.a7*s.qp[i-1] + s.qp[i]
+		}
+	} else {
+		// Special form of the recurrence if a1 is nearly zero
+		s.k[0] = 0
+		s.k[1] = -s.a7 * s.qp[0]
+		for i := 2; i < n; i++ {
+			s.k[i] = s.a3*s.qk[i-2] - s.a7*s.qp[i-1]
+		}
+	}
+}
+
+// Computes new estimates of the quadratic coefficients using the scalars
+// computed in calcSC.
+func (s *rpolyState) newEstimate(tFlag int) (float64, float64) {
+	if tFlag == 3 {
+		return 0, 0
+	}
+
+	n := s.n
+	var a4, a5 float64
+	if tFlag != 2 {
+		a4 = s.a + s.u*s.b + s.h*s.f
+		a5 = s.c + (s.u+s.v*s.f)*s.d
+	} else {
+		a4 = (s.a+s.g)*s.f + s.h
+		a5 = (s.f+s.u)*s.c + s.v*s.d
+	}
+
+	// Evaluate new quadratic coefficients
+	b1 := -s.k[n-1] / s.p[n]
+	b2 := -(s.k[n-2] + b1*s.p[n-1]) / s.p[n]
+	c1 := s.v * b2 * s.a1
+	c2 := b1 * s.a7
+	c3 := b1 * b1 * s.a3
+	c4 := c1 - c2 - c3
+	temp := a5 + b1*a4 - c4
+	if temp == 0 {
+		return 0, 0
+	}
+
+	uu := s.u - (s.u*(c3+c2)+s.v*(b1*s.a1+b2*s.a7))/temp
+	vv := s.v * (1.0 + c4/temp)
+	return uu, vv
+}
+
+// Divides the first nn coefficients of p by the quadratic x^2 + ux + v,
+// placing the quotient in q and returning the remainder coefficients a, b.
+func quadSyntheticDiv(nn int, u float64, v float64, p []float64, q []float64) (float64, float64) {
+	b := p[0]
+	q[0] = b
+	a := p[1] - b*u
+	q[1] = a
+	for i := 2; i < nn; i++ {
+		q[i] = p[i] - (a*u + b*v)
+		b = a
+		a = q[i]
+	}
+	return a, b
+}
+
+// Calculates the roots of the quadratic a*x^2 + b1*x + c. Returns the
+// smaller root sr + i*si and the larger root lr + i*li. The discriminant
+// is computed so that it does not overflow.
+func quadraticZeros(a float64, b1 float64, c float64) (float64, float64, float64, float64) {
+	var sr, si, lr, li float64
+
+	if a == 0 {
+		if b1 != 0 {
+			sr = -c / b1
+		}
+		return sr, si, lr, li
+	}
+	if c == 0 {
+		lr = -b1 / a
+		return sr, si, lr, li
+	}
+
+	var d, e float64
+	b := b1 / 2.0
+	if math.Abs(b) < math.Abs(c) {
+		e = a
+		if c < 0 {
+			e = -a
+		}
+		e = b*(b/math.Abs(c)) - e
+		d = math.Sqrt(math.Abs(e)) * math.Sqrt(math.Abs(c))
+	} else {
+		e = 1.0 - (a/b)*(c/b)
+		d = math.Sqrt(math.Abs(e)) * math.Abs(b)
+	}
+
+	if e >= 0 {
+		// Real roots
+		if b >= 0 {
+			d = -d
+		}
+		lr = (-b + d) / a
+		if lr != 0 {
+			sr = (c / lr) / a
+		}
+	} else {
+		// Complex conjugate roots
+		lr = -b / a
+		sr = lr
+		si = math.Abs(d / a)
+		li = -si
+	}
+
+	return sr, si, lr, li
+}
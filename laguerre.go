@@ -0,0 +1,132 @@
+package polynomials
+
+import (
+	"errors"
+	"math"
+	"math/cmplx"
+)
+
+// Laguerre's method
+// https://en.wikipedia.org/wiki/Laguerre%27s_method
+//
+// Roots are found one at a time starting from the origin, the polynomial
+// is deflated after each root and finally every root is polished against
+// the original polynomial. Laguerre's method converges from almost any
+// starting point, so no random starting points are needed and the result
+// is the same on every run.
+
+// Fractional steps used to break limit cycles
+var laguerreFractions = []float64{0.0, 0.5, 0.25, 0.75, 0.13, 0.38, 0.62, 0.88, 1.0}
+
+// Number of steps between fractional steps
+const laguerreCycle = 10
+
+func (poly *Polynomial) LaguerreRoots() ([]complex128, error) {
+	n := poly.Degree()
+	roots := make([]complex128, 0, n)
+
+	if n == 0 {
+		return roots, nil
+	}
+
+	original := make([]complex128, n+1)
+	for i, c := range poly.coeffs {
+		original[i] = complex(c, 0)
+	}
+
+	deflated := append([]complex128{}, original...)
+
+	for m := n; m >= 1; m-- {
+		root, ok := laguerre(deflated, complex(0, 0))
+		if !ok {
+			return roots, errors.New("Laguerre's method didn't converge before max number of iteration was reached! Result may be incorrect")
+		}
+
+		if math.Abs(imag(root)) <= 2.0*EpsLaguerre*math.Abs(real(root)) {
+			root = complex(real(root), 0)
+		}
+		roots = append(roots, root)
+
+		// Deflate by synthetic division with (x - root)
+		b := deflated[0]
+		for i := 1; i < m; i++ {
+			b = deflated[i] + b*root
+			deflated[i] = b
+		}
+		deflated = deflated[:m]
+	}
+
+	// Polish the roots against the original polynomial
+	for idx, root := range roots {
+		polished, _ := laguerre(original, root)
+		if imag(root) == 0 {
+			polished = complex(real(polished), 0)
+		}
+		roots[idx] = polished
+	}
+
+	return roots, nil
+}
+
+// Runs Laguerre's iteration for the polynomial with the given coefficients,
+// ordered decreasingly by degree, starting from x. Returns false if the
+// iteration didn't converge.
+func laguerre(coeffs []complex128, x complex128) (complex128, bool) {
+	m := len(coeffs) - 1
+	fm := complex(float64(m), 0)
+
+	for iter := 1; iter <= LaguerreMaxIter; iter++ {
+		// Evaluate p, p' and p''/2 with Horner's method, together with
+		// an estimate of the rounding error in p
+		b := coeffs[0]
+		d := complex(0, 0)
+		f := complex(0, 0)
+		err := cmplx.Abs(b)
+		abx := cmplx.Abs(x)
+
+		for i := 1; i <= m; i++ {
+			f = x*f + d
+			d = x*d + b
+			b = x*b + coeffs[i]
+			err = cmplx.Abs(b) + abx*err
+		}
+		err *= EpsLaguerre
+
+		if cmplx.Abs(b) <= err {
+			return x, true
+		}
+
+		g := d / b
+		g2 := g * g
+		h := g2 - 2.0*f/b
+		sq := cmplx.Sqrt((fm - 1) * (fm*h - g2))
+		gp := g + sq
+		gm := g - sq
+		if cmplx.Abs(gp) < cmplx.Abs(gm) {
+			gp = gm
+		}
+
+		var dx complex128
+		if cmplx.Abs(gp) > 0 {
+			dx = fm / gp
+		} else {
+			dx = complex(1+abx, 0) * cmplx.Exp(complex(0, float64(iter)))
+		}
+
+		x1 := x - dx
+
+		// The step is within a few ulps of x, further iterations would only
+		// oscillate around the root
+		if cmplx.Abs(dx) <= 4.0*EpsLaguerre*cmplx.Abs(x1) {
+			return x1, true
+		}
+
+		if iter%laguerreCycle != 0 {
+			x = x1
+		} else {
+			x -= complex(laguerreFractions[(iter/laguerreCycle)%len(laguerreFractions)], 0) * dx
+		}
+	}
+
+	return x, false
+}
@@ -3,6 +3,7 @@ package polynomials
 
 import (
 	"errors"
+	"math"
 	"gonum.org/v1/gonum/mat"
 )
 
@@ -35,4 +36,91 @@ func (poly *Polynomial) CompanionMatrix() (*mat.Dense, error) {
 	}
 
 	return matrix, nil
-}
\ No newline at end of file
+}
+
+// Computes the Sylvester matrix of two polynomials of degrees m and n.
+// The first n rows hold the shifted coefficients of poly1 and the last m rows
+// the shifted coefficients of poly2.
+// REFER TO: https://en.wikipedia.org/wiki/Sylvester_matrix
+
+func SylvesterMatrix(poly1 *Polynomial, poly2 *Polynomial) *mat.Dense {
+	if poly1 == nil || poly2 == nil {
+		panic("received nil *Polynomial")
+	}
+
+	m := poly1.Degree()
+	n := poly2.Degree()
+
+	if m+n == 0 {
+		panic("Sylvester matrix of two constant polynomials is empty")
+	}
+
+	matrix := mat.NewDense(m+n, m+n, nil)
+
+	for i := 0; i < n; i++ {
+		for j, c := range poly1.coeffs {
+			matrix.Set(i, i+j, c)
+		}
+	}
+
+	for i := 0; i < m; i++ {
+		for j, c := range poly2.coeffs {
+			matrix.Set(n+i, i+j, c)
+		}
+	}
+
+	return matrix
+}
+
+
+// Computes the resultant of two polynomials as the determinant of their Sylvester matrix.
+// The resultant is zero if and only if the polynomials have a common root.
+// REFER TO: https://en.wikipedia.org/wiki/Resultant
+
+func Resultant(poly1 *Polynomial, poly2 *Polynomial) float64 {
+	if poly1.IsZero() || poly2.IsZero() {
+		return 0
+	}
+
+	m := poly1.Degree()
+	n := poly2.Degree()
+
+	// Res(a, q) = a^n and Res(p, b) = b^m for constants a and b
+	if m == 0 {
+		return math.Pow(poly1.coeffs[0], float64(n))
+	}
+	if n == 0 {
+		return math.Pow(poly2.coeffs[0], float64(m))
+	}
+
+	return mat.Det(SylvesterMatrix(poly1, poly2))
+}
+
+
+// Computes the discriminant of the polynomial,
+// (-1)^(n(n-1)/2) / a_n * Res(p, p')
+// The discriminant is zero if and only if the polynomial has a multiple root.
+// For a polynomial with real coefficients and distinct roots it is positive
+// if the number of non-real roots is divisible by four, and negative otherwise.
+// REFER TO: https://en.wikipedia.org/wiki/Discriminant
+
+func (poly *Polynomial) Discriminant() float64 {
+	n := poly.Degree()
+
+	if n < 1 {
+		return 0
+	}
+
+	if n == 2 {
+		// Same as in the quadratic formula
+		a, b, c, exp := scaledQuadratic(poly.coeffs[0], poly.coeffs[1], poly.coeffs[2])
+		return math.Ldexp(quadraticDiscriminant(a, b, c), 2*exp)
+	}
+
+	disc := Resultant(poly, poly.Derivative()) / poly.LeadingCoeff()
+	if (n*(n-1)/2)%2 == 1 {
+		disc = -disc
+	}
+
+	return disc
+}
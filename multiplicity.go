@@ -0,0 +1,199 @@
+package polynomials
+
+import (
+	"errors"
+	"math"
+	"math/cmplx"
+	"sort"
+)
+
+// A Root is a distinct root of a polynomial together with its multiplicity
+type Root struct {
+	Value        complex128
+	Multiplicity int
+}
+
+// RootsWithMultiplicity returns the distinct complex roots of the polynomial
+// together with their multiplicities, sorted by real and then by imaginary part.
+//
+// Repeated roots are returned by the solvers as clusters of nearby roots.
+// The clusters are found by grouping roots closer than RootClusterTol to each
+// other, and a cluster of m roots is accepted as a root of multiplicity m if
+// the polynomial and its first m-1 derivatives vanish at its centroid up to
+// rounding errors. The centroid is refined
+// first with Newton's method on the (m-1)th derivative, of which a root of
+// multiplicity m is a simple root. Clusters that fail the test are split
+// with a tighter tolerance.
+func (poly *Polynomial) RootsWithMultiplicity() ([]Root, error) {
+	if poly.IsZero() {
+		return nil, errors.New("infinitely many solutions")
+	}
+
+	roots, err := poly.allComplexRoots()
+	if err != nil {
+		return nil, err
+	}
+
+	derivs := []*Polynomial{poly}
+	for i := 1; i <= poly.Degree(); i++ {
+		derivs = append(derivs, derivs[i-1].Derivative())
+	}
+
+	result := []Root{}
+	for _, group := range clusterRoots(roots, RootClusterTol) {
+		result = append(result, resolveCluster(derivs, group, RootClusterTol)...)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if real(result[i].Value) != real(result[j].Value) {
+			return real(result[i].Value) < real(result[j].Value)
+		}
+		return imag(result[i].Value) < imag(result[j].Value)
+	})
+
+	return result, nil
+}
+
+// Returns all roots of the polynomial, repeated roots included, using the
+// solving method of the polynomial. BisectionNewton cannot find complex
+// roots, so the eigenvalue method is used in its place. The roots are
+// solved from a copy, since the eigenvalue method makes its receiver monic.
+func (poly *Polynomial) allComplexRoots() ([]complex128, error) {
+	if poly.Degree() == 2 {
+		roots := poly.QuadraticRoots()
+		if len(roots) == 1 {
+			roots = append(roots, roots[0])
+		}
+		return roots, nil
+	}
+
+	p := CreatePolynomial(poly.coeffs...)
+	if poly.SolveMode == BisectionNewton {
+		return p.ComplexRootsEigenvalue()
+	}
+
+	p.SolveMode = poly.SolveMode
+	return p.ComplexRoots()
+}
+
+// Groups roots lying within a relative distance tol of each other
+func clusterRoots(roots []complex128, tol float64) [][]complex128 {
+	n := len(roots)
+	visited := make([]bool, n)
+	groups := [][]complex128{}
+
+	for i := 0; i < n; i++ {
+		if visited[i] {
+			continue
+		}
+		visited[i] = true
+		group := []complex128{roots[i]}
+
+		for k := 0; k < len(group); k++ {
+			for j := 0; j < n; j++ {
+				if visited[j] {
+					continue
+				}
+				scale := math.Max(1.0, math.Max(cmplx.Abs(group[k]), cmplx.Abs(roots[j])))
+				if cmplx.Abs(group[k]-roots[j]) <= tol*scale {
+					visited[j] = true
+					group = append(group, roots[j])
+				}
+			}
+		}
+		groups = append(groups, group)
+	}
+
+	return groups
+}
+
+// Turns a cluster of roots into one or more distinct roots. derivs holds
+// the polynomial followed by its successive derivatives.
+func resolveCluster(derivs []*Polynomial, group []complex128, tol float64) []Root {
+	m := len(group)
+	if m == 1 {
+		return []Root{{Value: RoundC(group[0]), Multiplicity: 1}}
+	}
+
+	centroid := complex(0, 0)
+	for _, z := range group {
+		centroid += z
+	}
+	centroid /= complex(float64(m), 0)
+	centroid = refineMultipleRoot(derivs, centroid, m)
+
+	if isMultipleRoot(derivs, centroid, m) {
+		return []Root{{Value: RoundC(centroid), Multiplicity: m}}
+	}
+
+	tol /= 10.0
+	if tol < EpsMultiplicity {
+		result := []Root{}
+		for _, z := range group {
+			result = append(result, Root{Value: RoundC(z), Multiplicity: 1})
+		}
+		return result
+	}
+
+	result := []Root{}
+	for _, sub := range clusterRoots(group, tol) {
+		result = append(result, resolveCluster(derivs, sub, tol)...)
+	}
+	return result
+}
+
+// Newton's method on the (m-1)th derivative starting from z
+func refineMultipleRoot(derivs []*Polynomial, z complex128, m int) complex128 {
+	if m >= len(derivs) {
+		return z
+	}
+
+	for i := 0; i < MaxNewtonIterations; i++ {
+		d := derivs[m].evalComplex(z)
+		if d == 0 {
+			break
+		}
+		step := derivs[m-1].evalComplex(z) / d
+		z -= step
+		if cmplx.Abs(step) <= EpsLaguerre*cmplx.Abs(z) {
+			break
+		}
+	}
+
+	return z
+}
+
+// Tests whether p and its first m-1 derivatives vanish at z, that is
+// whether they are within the rounding error of Horner's method. The error
+// is bounded by 2n machEps times the sum of the magnitudes of the terms,
+// with a safety factor of 8 for the error of z itself.
+func isMultipleRoot(derivs []*Polynomial, z complex128, m int) bool {
+	if m >= len(derivs) {
+		return false
+	}
+
+	r := cmplx.Abs(z)
+	for k := 0; k < m; k++ {
+		val := complex(0, 0)
+		bound := 0.0
+		for _, c := range derivs[k].coeffs {
+			val = val*z + complex(c, 0)
+			bound = bound*r + math.Abs(c)
+		}
+		tol := 16.0 * float64(len(derivs[k].coeffs)) * machEps
+		if cmplx.Abs(val) > tol*bound {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Horner's method for complex z without rounding the result
+func (poly *Polynomial) evalComplex(z complex128) complex128 {
+	t := complex(0, 0)
+	for _, c := range poly.coeffs {
+		t = t*z + complex(c, 0)
+	}
+	return t
+}
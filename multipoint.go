@@ -0,0 +1,242 @@
+package polynomials
+
+import (
+	"math"
+	"runtime"
+	"sync"
+)
+
+// Multipoint evaluation and interpolation with subproduct trees
+// https://en.wikipedia.org/wiki/Polynomial_evaluation#Multipoint_evaluation
+//
+// The products of the linear factors x - x_i over pairs, quarters, ... of
+// the points form a subproduct tree. The value at x_i is the remainder of
+// the polynomial modulo x - x_i, which is found by reducing the polynomial
+// modulo the products from the root of the tree down to the leaves. With
+// fast multiplication and division this takes O(n log^2 n) instead of the
+// O(n^2) of Horner's method, but only pays off for many points.
+//
+// Calls with at least MultipointThreshold points in total are evaluated with
+// subproduct trees, in blocks of as many points as the polynomial has
+// coefficients, since the polynomial is its own remainder modulo longer
+// products. Calls with fewer points use Horner's method. Reducing modulo
+// products of many real linear factors is ill conditioned, so about sqrt(n)
+// of the values of every block are compared with Horner's method, and
+// Horner's method is used for the whole block if any differs by more than
+// EpsMultipoint relative to sum |c_k| |x|^k. The values that are not
+// compared are not guaranteed to be as accurate. Building a tree over a
+// block costs about as much as Horner's method up to a degree of a few
+// thousand, and for points spread over an interval the check usually fails,
+// so splitting Horner's method over several goroutines with
+// EvaluateManyParallel is the faster way to evaluate polynomials of
+// moderate degree at many points.
+
+// EvaluateMany returns the values of the polynomial at the points xs. They
+// are the values of At, except for at least MultipointThreshold points,
+// which are computed with subproduct trees and agree with At only up to the
+// sampled error.
+func (poly *Polynomial) EvaluateMany(xs []float64) []float64 {
+	values := make([]float64, len(xs))
+	poly.evaluateInto(xs, values, len(xs) >= MultipointThreshold)
+	return values
+}
+
+// EvaluateManyParallel is EvaluateMany split over workers goroutines, or
+// over runtime.GOMAXPROCS(0) goroutines if workers <= 0
+func (poly *Polynomial) EvaluateManyParallel(xs []float64, workers int) []float64 {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(xs) {
+		workers = len(xs)
+	}
+
+	// Decided on all points, not on the share of a goroutine
+	useTree := len(xs) >= MultipointThreshold
+
+	values := make([]float64, len(xs))
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		lo := w * len(xs) / workers
+		hi := (w + 1) * len(xs) / workers
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			poly.evaluateInto(xs[lo:hi], values[lo:hi], useTree)
+		}()
+	}
+	wg.Wait()
+
+	return values
+}
+
+func (poly *Polynomial) evaluateInto(xs []float64, values []float64, useTree bool) {
+	size := len(poly.coeffs)
+	if size == 0 {
+		size = 1
+	}
+	for start := 0; start < len(xs); start += size {
+		end := start + size
+		if end > len(xs) {
+			end = len(xs)
+		}
+		block := xs[start:end]
+		if useTree {
+			tree := subproductTree(block)
+			if remainderTree(poly.coeffs, tree, block, values[start:end]) {
+				for i := start; i < end; i++ {
+					values[i] = Round(values[i])
+				}
+				continue
+			}
+		}
+		for i, x := range block {
+			values[start+i] = poly.At(x)
+		}
+	}
+}
+
+// InterpolateFast returns the polynomial of degree below len(xs) with
+// p(xs[i]) = ys[i], computed with a subproduct tree. The points must be
+// distinct. About sqrt(n) of the samples are checked against the result,
+// and InterpolateNewton is used instead if any differs by more than
+// EpsMultipoint relative to the largest of the values.
+func InterpolateFast(xs []float64, ys []float64) (*Polynomial, error) {
+	if err := checkSamples(xs, ys); err != nil {
+		return nil, err
+	}
+	if len(xs) == 0 {
+		return CreatePolynomial(), nil
+	}
+
+	// Lagrange's formula p = sum w_i m / (x - x_i) with m = prod (x - x_i)
+	// and w_i = y_i / m'(x_i)
+	tree := subproductTree(xs)
+	root := tree[len(tree)-1][0]
+	deriv := make([]float64, len(root)-1)
+	for i := range deriv {
+		deriv[i] = root[i] * float64(len(root)-1-i)
+	}
+
+	weights := make([]float64, len(xs))
+	if !remainderTree(deriv, tree, xs, weights) {
+		for i := range xs {
+			weights[i] = 0
+			for _, c := range deriv {
+				weights[i] = weights[i]*xs[i] + c
+			}
+		}
+	}
+	for i := range weights {
+		weights[i] = ys[i] / weights[i]
+	}
+
+	// Sums of the terms over the nodes of the tree from the leaves up,
+	// s = s_left m_right + s_right m_left
+	sums := make([][]float64, len(weights))
+	for i, w := range weights {
+		sums[i] = []float64{w}
+	}
+	for level := 0; level < len(tree)-1; level++ {
+		nodes := tree[level]
+		next := make([][]float64, (len(nodes)+1)/2)
+		for j := range next {
+			if 2*j+1 == len(nodes) {
+				next[j] = sums[2*j]
+				continue
+			}
+			left := convolve(sums[2*j], nodes[2*j+1])
+			right := convolve(sums[2*j+1], nodes[2*j])
+			// Both are one shorter than the product of the nodes
+			for k := range left {
+				left[k] += right[k]
+			}
+			next[j] = left
+		}
+		sums = next
+	}
+
+	// The check is relative to the values rather than the coefficients,
+	// which grow large when the sums up the tree cancel
+	scale := maxAbs(ys)
+	step := int(math.Sqrt(float64(len(xs)))) + 1
+	for i := 0; i < len(xs); i += step {
+		value := 0.0
+		for _, c := range sums[0] {
+			value = value*xs[i] + c
+		}
+		if math.Abs(value-ys[i]) > EpsMultipoint*scale || math.IsNaN(value) {
+			return InterpolateNewton(xs, ys)
+		}
+	}
+
+	return CreatePolynomial(sums[0]...), nil
+}
+
+// Subproduct tree of the points. The first level holds the linear factors
+// x - x_i and every level above holds the products of pairs of nodes of the
+// level below, with the odd one out carried over. The last level holds the
+// product of all factors.
+func subproductTree(xs []float64) [][][]float64 {
+	nodes := make([][]float64, len(xs))
+	for i, x := range xs {
+		nodes[i] = []float64{1, -x}
+	}
+
+	tree := [][][]float64{nodes}
+	for len(nodes) > 1 {
+		next := make([][]float64, (len(nodes)+1)/2)
+		for j := range next {
+			if 2*j+1 == len(nodes) {
+				next[j] = nodes[2*j]
+				continue
+			}
+			next[j] = convolve(nodes[2*j], nodes[2*j+1])
+		}
+		tree = append(tree, next)
+		nodes = next
+	}
+
+	return tree
+}
+
+// Writes the values of the leading-first coefficients at the points of the
+// tree into values by reducing them down the tree. Returns false if a
+// sample of the values differs from Horner's method by more than
+// EpsMultipoint.
+func remainderTree(coeffs []float64, tree [][][]float64, xs []float64, values []float64) bool {
+	rems := [][]float64{coeffs}
+	for level := len(tree) - 1; level >= 0; level-- {
+		nodes := tree[level]
+		next := make([][]float64, len(nodes))
+		for j, node := range nodes {
+			next[j] = rems[j/2]
+			if len(next[j]) >= len(node) {
+				_, next[j] = divide(next[j], node)
+			}
+		}
+		rems = next
+	}
+
+	for i, r := range rems {
+		values[i] = 0
+		if len(r) > 0 {
+			values[i] = r[len(r)-1]
+		}
+	}
+
+	// Sample about sqrt(n) of the values
+	step := int(math.Sqrt(float64(len(xs)))) + 1
+	for i := 0; i < len(xs); i += step {
+		want, bound := 0.0, 0.0
+		for _, c := range coeffs {
+			want = want*xs[i] + c
+			bound = bound*math.Abs(xs[i]) + math.Abs(c)
+		}
+		if math.Abs(values[i]-want) > EpsMultipoint*bound || math.IsNaN(values[i]) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -30,4 +30,54 @@ func (poly *Polynomial) NewtonMethod(guess float64) (float64, error) {
 	}
 
 	return root, errors.New("NewtonRaphson didn't converge before max number of iteration was reached! Result may be incorrect")
-}
\ No newline at end of file
+}
+
+// Newton's method safeguarded by bisection on an interval holding a single
+// root, at whose ends the polynomial has opposite signs. Steps that would
+// leave the interval are replaced by bisection steps, so the iteration
+// cannot wander off to a root outside of the interval. If the ends do not
+// bracket the root, plain Newton's method is used from the midpoint.
+func (poly *Polynomial) newtonWithin(interval Interval) (float64, error) {
+	lo, hi := interval.A, interval.B
+	if lo == hi {
+		return lo, nil
+	}
+
+	pLo := poly.At(lo)
+	pHi := poly.At(hi)
+	if pLo == 0.0 || pHi == 0.0 || (pLo < 0) == (pHi < 0) {
+		return poly.NewtonMethod(interval.Mid())
+	}
+
+	deriv := poly.Derivative()
+	root := interval.Mid()
+
+	// Each bisection step halves the interval
+	for i := 0; i < MaxNewtonIterations+64; i++ {
+		p := poly.At(root)
+		if p == 0.0 {
+			return root, nil
+		}
+		if (p < 0) == (pLo < 0) {
+			lo = root
+		} else {
+			hi = root
+		}
+
+		next := lo + (hi-lo)/2.0
+		derivAtRoot := deriv.At(root)
+		if derivAtRoot != 0.0 {
+			step := root - p/derivAtRoot
+			if step > lo && step < hi {
+				next = step
+			}
+		}
+
+		if math.Abs(next - root) < EpsNewton {
+			return next, nil
+		}
+		root = next
+	}
+
+	return root, errors.New("NewtonRaphson didn't converge before max number of iteration was reached! Result may be incorrect")
+}
@@ -2,6 +2,9 @@ package polynomials
 
 import (
 	"fmt"
+	"math"
+	"math/cmplx"
+	"sort"
 	"testing"
 )
 
@@ -204,7 +207,7 @@ func TestRealRoots1(t *testing.T) {
 func TestDurandKerner(t *testing.T){
 	 poly := CreatePolynomial(1, -26.736792368991583, 189.80002662743738, -148.2021748787599, 30.65476667810361)
 	 poly.SolveMode = DurandKerner
-	 roots, err := poly.ComplexRoots()
+	 roots, err := poly.ComplexRootsDurandKerner()
 	 if err != nil {
 	 	t.Fatalf(`RealRoots() errored: %v`, err)
 	 }
@@ -503,3 +506,1765 @@ func TestString(t *testing.T) {
 
 	fmt.Println("String ................ OK")
 }
+
+func TestAberth(t *testing.T) {
+	poly := CreatePolynomial(1, -26.736792368991583, 189.80002662743738, -148.2021748787599, 30.65476667810361)
+	poly.SolveMode = Aberth
+	roots, err := poly.ComplexRootsAberth()
+	if err != nil {
+		t.Fatalf(`ComplexRootsAberth() errored: %v`, err)
+	}
+
+	solutions := []complex128{
+		complex(0.407229454336, 0),
+		complex(0.449563948234, 0),
+		complex(12.918832236262, 0),
+		complex(12.961166730159, 0),
+	}
+
+	for _, solution := range solutions {
+		found := false
+		for _, root := range roots {
+			if cmplx.Abs(root-solution) < 1e-9 {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Fatalf(`Aberth failed to find root %v. Found roots: %v`, solution, roots)
+		}
+	}
+
+	// Wilkinson-like polynomial of degree 60 with roots 1/60, 2/60, ..., 1
+	n := 60
+	poly = CreatePolynomial(1)
+	for k := 1; k <= n; k++ {
+		poly = poly.Mult(CreatePolynomial(1, -float64(k)/float64(n)))
+	}
+	poly.SolveMode = Aberth
+
+	roots, err = poly.AberthRoots()
+	if err != nil {
+		t.Fatalf(`AberthRoots() errored: %v`, err)
+	}
+	if len(roots) != n {
+		t.Fatalf(`AberthRoots() returned %d roots, expected %d`, len(roots), n)
+	}
+
+	fmt.Println("Aberth ................ OK")
+}
+
+func TestJenkinsTraub(t *testing.T) {
+	// Complex roots are the same as in TestComplexRoots
+	poly := CreatePolynomial(1.0, 3.0, -1.5, -8.0, -12.5)
+	poly.SolveMode = JenkinsTraub
+
+	roots, err := poly.ComplexRootsJenkinsTraub()
+	if err != nil {
+		t.Fatalf(`ComplexRootsJenkinsTraub() errored: %v`, err)
+	}
+
+	solutions := []complex128{
+		complex(1.8892177902751495, 0),
+		complex(-3.071745756398733, 0),
+		complex(-0.9087360169382082, 1.152468686777906),
+		complex(-0.9087360169382082, -1.152468686777906),
+	}
+
+	if len(roots) != len(solutions) {
+		t.Fatalf(`JenkinsTraub returned %d roots, expected %d: %v`, len(roots), len(solutions), roots)
+	}
+
+	for _, solution := range solutions {
+		found := false
+		for _, root := range roots {
+			if cmplx.Abs(root-solution) < 1e-9 {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Fatalf(`JenkinsTraub failed to find root %v. Found roots: %v`, solution, roots)
+		}
+	}
+
+	realRoots := getRealParts(roots)
+	if len(realRoots) != 2 {
+		t.Fatalf(`JenkinsTraub returned real roots %v, expected two real roots`, realRoots)
+	}
+
+	fmt.Println("Jenkins-Traub ......... OK")
+}
+
+func TestLaguerre(t *testing.T) {
+	poly := CreatePolynomial(1, -26.736792368991583, 189.80002662743738, -148.2021748787599, 30.65476667810361)
+	poly.SolveMode = Laguerre
+
+	roots, err := poly.ComplexRootsLaguerre()
+	if err != nil {
+		t.Fatalf(`ComplexRootsLaguerre() errored: %v`, err)
+	}
+
+	solutions := []complex128{
+		complex(0.407229454336, 0),
+		complex(0.449563948234, 0),
+		complex(12.918832236262, 0),
+		complex(12.961166730159, 0),
+	}
+
+	for _, solution := range solutions {
+		found := false
+		for _, root := range roots {
+			if cmplx.Abs(root-solution) < 1e-9 {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Fatalf(`Laguerre failed to find root %v. Found roots: %v`, solution, roots)
+		}
+	}
+
+	// Results must not differ between runs
+	again, err := poly.ComplexRootsLaguerre()
+	if err != nil {
+		t.Fatalf(`ComplexRootsLaguerre() errored: %v`, err)
+	}
+	for idx := range roots {
+		if roots[idx] != again[idx] {
+			t.Fatalf(`Laguerre returned different roots on consecutive runs: %v and %v`, roots, again)
+		}
+	}
+
+	fmt.Println("Laguerre .............. OK")
+}
+
+func TestRootsWithMultiplicity(t *testing.T) {
+	methods := []SolvingMethod{DurandKerner, BisectionNewton, Eigenvalue, Aberth, JenkinsTraub, Laguerre}
+
+	for _, method := range methods {
+		// (x-1)^3
+		poly := CreatePolynomial(1, -3, 3, -1)
+		poly.SolveMode = method
+
+		roots, err := poly.RootsWithMultiplicity()
+		if err != nil {
+			t.Fatalf(`RootsWithMultiplicity() errored: %v`, err)
+		}
+		if len(roots) != 1 || roots[0].Value != complex(1, 0) || roots[0].Multiplicity != 3 {
+			t.Fatalf(`RootsWithMultiplicity() returned %v for (x-1)^3 with solve mode %d`, roots, method)
+		}
+
+		// (x+1)(x-2)^2 (x^2+1)
+		poly = CreatePolynomial(1, -3, 1, 1, 0, 4)
+		poly.SolveMode = method
+
+		roots, err = poly.RootsWithMultiplicity()
+		if err != nil {
+			t.Fatalf(`RootsWithMultiplicity() errored: %v`, err)
+		}
+
+		expected := []Root{
+			{Value: complex(-1, 0), Multiplicity: 1},
+			{Value: complex(0, -1), Multiplicity: 1},
+			{Value: complex(0, 1), Multiplicity: 1},
+			{Value: complex(2, 0), Multiplicity: 2},
+		}
+		if len(roots) != len(expected) {
+			t.Fatalf(`RootsWithMultiplicity() returned %v, expected %v with solve mode %d`, roots, expected, method)
+		}
+		for idx := range expected {
+			if roots[idx] != expected[idx] {
+				t.Fatalf(`RootsWithMultiplicity() returned %v, expected %v with solve mode %d`, roots, expected, method)
+			}
+		}
+	}
+
+	// Double root of a quadratic
+	poly := CreatePolynomial(1, -2, 1)
+	roots, err := poly.RootsWithMultiplicity()
+	if err != nil {
+		t.Fatalf(`RootsWithMultiplicity() errored: %v`, err)
+	}
+	if len(roots) != 1 || roots[0].Value != complex(1, 0) || roots[0].Multiplicity != 2 {
+		t.Fatalf(`RootsWithMultiplicity() returned %v for (x-1)^2`, roots)
+	}
+
+	// Close but distinct roots must not be merged
+	poly = CreatePolynomial(1, -2.001, 1.001)
+	roots, err = poly.RootsWithMultiplicity()
+	if err != nil {
+		t.Fatalf(`RootsWithMultiplicity() errored: %v`, err)
+	}
+	if len(roots) != 2 {
+		t.Fatalf(`RootsWithMultiplicity() returned %v for (x-1)(x-1.001)`, roots)
+	}
+
+	poly = CreatePolynomial(1, -2.0001, 1.0001).Mult(CreatePolynomial(1, 3))
+	roots, err = poly.RootsWithMultiplicity()
+	if err != nil {
+		t.Fatalf(`RootsWithMultiplicity() errored: %v`, err)
+	}
+	if len(roots) != 3 {
+		t.Fatalf(`RootsWithMultiplicity() returned %v for (x-1)(x-1.0001)(x+3)`, roots)
+	}
+
+	fmt.Println("Root Multiplicity ..... OK")
+}
+
+func TestGCD(t *testing.T) {
+	// (x-1)(x-2) and (x-2)(x-3)
+	g := GCD(CreatePolynomial(1, -3, 2), CreatePolynomial(1, -5, 6))
+	if g.Degree() != 1 || g.coeffs[0] != 1.0 || Round(g.coeffs[1]) != -2.0 {
+		t.Fatalf(`GCD() returned %v. Expected x - 2`, g.coeffs)
+	}
+
+	g = GCD(CreatePolynomial(1, -3, 2), CreatePolynomial(1, 5, 6))
+	if g.Degree() != 0 || g.coeffs[0] != 1.0 {
+		t.Fatalf(`GCD() returned %v for coprime polynomials. Expected 1`, g.coeffs)
+	}
+
+	fmt.Println("GCD ................... OK")
+}
+
+func TestSquarefree(t *testing.T) {
+	// (x-1)^3 (x+2) (x+0.5)^2
+	poly := CreatePolynomial(1, -3, 3, -1).Mult(CreatePolynomial(1, 2)).Mult(CreatePolynomial(1, 0.5)).Mult(CreatePolynomial(1, 0.5))
+
+	sqf := poly.SquarefreePart()
+	if sqf.Degree() != 3 {
+		t.Fatalf(`SquarefreePart() returned %v. Expected a polynomial of degree 3`, sqf)
+	}
+
+	factors := poly.SquarefreeFactorization()
+	expected := [][]float64{{1, 2}, {1, 0.5}, {1, -1}}
+	if len(factors) != len(expected) {
+		t.Fatalf(`SquarefreeFactorization() returned %d factors. Expected %d`, len(factors), len(expected))
+	}
+	for i, factor := range factors {
+		if factor.Degree() != len(expected[i])-1 {
+			t.Fatalf(`SquarefreeFactorization() returned %v for multiplicity %d. Expected %v`, factor.coeffs, i+1, expected[i])
+		}
+		for j, c := range expected[i] {
+			if math.Abs(factor.coeffs[j]-c) > 1e-9 {
+				t.Fatalf(`SquarefreeFactorization() returned %v for multiplicity %d. Expected %v`, factor.coeffs, i+1, expected[i])
+			}
+		}
+	}
+
+	// Bisection + Newton must handle the repeated roots
+	poly.SolveMode = BisectionNewton
+	roots, err := poly.RealRoots()
+	if err != nil {
+		t.Fatalf(`RealRoots() errored: %v`, err)
+	}
+
+	solutions := map[float64]bool{-2.0: true, -0.5: true, 1.0: true}
+	for _, root := range roots {
+		delete(solutions, root)
+	}
+	if len(roots) != 3 || len(solutions) != 0 {
+		t.Fatalf(`RealRoots() returned %v. Expected [-2 -0.5 1]`, roots)
+	}
+
+	// Close distinct roots look like a common factor of p and p' to GCD,
+	// but are not merged
+	near := CreatePolynomial(1, -1).Mult(CreatePolynomial(1, -1.00001)).Mult(CreatePolynomial(1, 3)).Mult(CreatePolynomial(1, 0, 1)).Mult(CreatePolynomial(1, -5))
+	if sqf := near.SquarefreePart(); sqf.Degree() != 6 {
+		t.Fatalf(`SquarefreePart() returned %v. Expected a polynomial of degree 6`, sqf)
+	}
+	if factors := near.SquarefreeFactorization(); len(factors) != 1 || factors[0].Degree() != 6 {
+		t.Fatalf(`SquarefreeFactorization() returned %v. Expected a single factor of degree 6`, factors)
+	}
+	near.SolveMode = BisectionNewton
+	roots, err = near.RealRoots()
+	expectedRoots := []float64{-3, 1, 1.00001, 5}
+	if err != nil || len(roots) != len(expectedRoots) {
+		t.Fatalf(`RealRoots() returned %v, %v. Expected %v`, roots, err, expectedRoots)
+	}
+	for i, root := range roots {
+		if math.Abs(root-expectedRoots[i]) > 1e-8 {
+			t.Fatalf(`RealRoots() returned %v. Expected %v`, roots, expectedRoots)
+		}
+	}
+
+	fmt.Println("Squarefree ............ OK")
+}
+
+func TestResultant(t *testing.T) {
+	// (x-1)(x-2) and (x-3)
+	res := Resultant(CreatePolynomial(1, -3, 2), CreatePolynomial(1, -3))
+	if Round(res) != 2.0 {
+		t.Fatalf(`Resultant() returned %v. Expected 2`, res)
+	}
+
+	// Common root
+	res = Resultant(CreatePolynomial(1, -3, 2), CreatePolynomial(1, -5, 6))
+	if Round(res) != 0.0 {
+		t.Fatalf(`Resultant() returned %v for polynomials with a common root. Expected 0`, res)
+	}
+
+	s := SylvesterMatrix(CreatePolynomial(1, -3, 2), CreatePolynomial(1, -3))
+	if r, c := s.Dims(); r != 3 || c != 3 || s.At(0, 2) != 2 || s.At(1, 0) != 1 || s.At(2, 1) != 1 || s.At(2, 2) != -3 {
+		t.Fatalf(`SylvesterMatrix() returned wrong matrix`)
+	}
+
+	fmt.Println("Resultant ............. OK")
+}
+
+func TestDiscriminant(t *testing.T) {
+	// x^3 - 3x + 1, discriminant -4p^3 - 27q^2 = 81
+	disc := CreatePolynomial(1, 0, -3, 1).Discriminant()
+	if Round(disc) != 81.0 {
+		t.Fatalf(`Discriminant() returned %v. Expected 81`, disc)
+	}
+
+	// x^4 + 1 has four non-real roots, discriminant 256
+	disc = CreatePolynomial(1, 0, 0, 0, 1).Discriminant()
+	if Round(disc) != 256.0 {
+		t.Fatalf(`Discriminant() returned %v. Expected 256`, disc)
+	}
+
+	// Multiple root
+	disc = CreatePolynomial(1, -3, 3, -1).Discriminant()
+	if Round(disc) != 0.0 {
+		t.Fatalf(`Discriminant() returned %v for (x-1)^3. Expected 0`, disc)
+	}
+
+	// Quadratic
+	disc = CreatePolynomial(-3.0, 1.33, -2.5).Discriminant()
+	if Round(disc) != Round(1.33*1.33-4.0*(-3.0)*(-2.5)) {
+		t.Fatalf(`Discriminant() returned %v. Expected %v`, disc, 1.33*1.33-4.0*(-3.0)*(-2.5))
+	}
+
+	fmt.Println("Discriminant .......... OK")
+}
+
+func TestCubic(t *testing.T) {
+	for poly, solutions := range map[*Polynomial][]complex128{
+		// Three real roots
+		CreatePolynomial(2, -4, -22, 24): {complex(1, 0), complex(-3, 0), complex(4, 0)},
+		// One real root
+		CreatePolynomial(1, -1, 1, -1): {complex(1, 0), complex(0, 1), complex(0, -1)},
+		// Double root
+		CreatePolynomial(1, 0, -3, 2): {complex(-2, 0), complex(1, 0), complex(1, 0)},
+	} {
+		roots, err := poly.ComplexRoots()
+		if err != nil {
+			t.Fatalf(`ComplexRoots() errored: %v`, err)
+		}
+
+		if len(roots) != 3 {
+			t.Fatalf(`ComplexRoots() returned %v for %v. Expected %v`, roots, poly, solutions)
+		}
+		for _, solution := range solutions {
+			found := false
+			for _, root := range roots {
+				if cmplx.Abs(root-solution) < 1e-7 {
+					found = true
+					break
+				}
+			}
+			if !found {
+				t.Fatalf(`ComplexRoots() returned %v for %v. Expected %v`, roots, poly, solutions)
+			}
+		}
+	}
+
+	fmt.Println("Cubic ................. OK")
+}
+
+func TestQuartic(t *testing.T) {
+	for poly, solutions := range map[*Polynomial][]complex128{
+		// (x-1)(x-2)(x+3)(x+4)
+		CreatePolynomial(1, 4, -7, -22, 24): {complex(1, 0), complex(2, 0), complex(-3, 0), complex(-4, 0)},
+		// Biquadratic x^4 - 5x^2 + 4
+		CreatePolynomial(1, 0, -5, 0, 4): {complex(1, 0), complex(-1, 0), complex(2, 0), complex(-2, 0)},
+		// x^4 + 1
+		CreatePolynomial(1, 0, 0, 0, 1): {
+			complex(math.Sqrt2/2, math.Sqrt2/2), complex(math.Sqrt2/2, -math.Sqrt2/2),
+			complex(-math.Sqrt2/2, math.Sqrt2/2), complex(-math.Sqrt2/2, -math.Sqrt2/2),
+		},
+	} {
+		roots, err := poly.ComplexRoots()
+		if err != nil {
+			t.Fatalf(`ComplexRoots() errored: %v`, err)
+		}
+
+		if len(roots) != 4 {
+			t.Fatalf(`ComplexRoots() returned %v for %v. Expected %v`, roots, poly, solutions)
+		}
+		for _, solution := range solutions {
+			found := false
+			for _, root := range roots {
+				if cmplx.Abs(root-solution) < 1e-9 {
+					found = true
+					break
+				}
+			}
+			if !found {
+				t.Fatalf(`ComplexRoots() returned %v for %v. Expected %v`, roots, poly, solutions)
+			}
+		}
+	}
+
+	fmt.Println("Quartic ............... OK")
+}
+
+func TestQuadraticHardCases(t *testing.T) {
+	// b^2 >> 4ac, the textbook formula loses the small root to cancellation
+	roots := CreatePolynomial(1, 1e8, 1).QuadraticRoots()
+	small := real(roots[1])
+	if len(roots) != 2 || math.Abs(small+1e-8)/1e-8 > 1e-15 || math.Abs(real(roots[0])+1e8)/1e8 > 1e-15 {
+		t.Fatalf(`QuadraticRoots() returned %v. Expected -1e8 and -1e-8`, roots)
+	}
+
+	// b^2 and 4ac overflow
+	roots = CreatePolynomial(1e300, -3e300, 2e300).QuadraticRoots()
+	if len(roots) != 2 || Round(real(roots[0])) != 2.0 || Round(real(roots[1])) != 1.0 {
+		t.Fatalf(`QuadraticRoots() returned %v. Expected 2 and 1`, roots)
+	}
+
+	// b^2 and 4ac underflow
+	roots = CreatePolynomial(1e-300, -3e-300, 2e-300).QuadraticRoots()
+	if len(roots) != 2 || Round(real(roots[0])) != 2.0 || Round(real(roots[1])) != 1.0 {
+		t.Fatalf(`QuadraticRoots() returned %v. Expected 2 and 1`, roots)
+	}
+
+	// Complex roots with large coefficients
+	roots = CreatePolynomial(1e300, 0, 4e300).QuadraticRoots()
+	if len(roots) != 2 || RoundC(roots[0]) != complex(0, 2) || RoundC(roots[1]) != complex(0, -2) {
+		t.Fatalf(`QuadraticRoots() returned %v. Expected 2i and -2i`, roots)
+	}
+
+	// b^2 close to 4ac, roots 1 +- sqrt(1 - c)
+	c := 1.0 - 1e-10
+	roots = CreatePolynomial(1, -2, c).QuadraticRoots()
+	d := math.Sqrt(1.0 - c)
+	if len(roots) != 2 || math.Abs(real(roots[0])-(1+d)) > 1e-15 || math.Abs(real(roots[1])-(1-d)) > 1e-15 {
+		t.Fatalf(`QuadraticRoots() returned %v. Expected %v and %v`, roots, 1+d, 1-d)
+	}
+
+	// Double root with rounded coefficients, (x - 0.1)^2
+	roots = CreatePolynomial(1, -0.2, 0.1*0.1).QuadraticRoots()
+	if len(roots) != 1 || Round(real(roots[0])) != 0.1 || imag(roots[0]) != 0 {
+		t.Fatalf(`QuadraticRoots() returned %v. Expected a double root 0.1`, roots)
+	}
+
+	// a underflows when scaled together with c, roots -5e199 +- 8.66e199i
+	roots = CreatePolynomial(1e-200, 1, 1e200).QuadraticRoots()
+	im := math.Sqrt(3) / 2 * 1e200
+	if len(roots) != 2 || math.Abs(real(roots[0])+5e199)/5e199 > 1e-14 || math.Abs(math.Abs(imag(roots[0]))-im)/im > 1e-14 ||
+		roots[1] != cmplx.Conj(roots[0]) {
+		t.Fatalf(`QuadraticRoots() returned %v. Expected -5e199 +- 8.66e199i`, roots)
+	}
+
+	// b^2 underflows and 4ac overflows, roots +- 1e300
+	roots = CreatePolynomial(1e-300, 1e-300, -1e300).QuadraticRoots()
+	if len(roots) != 2 || math.Abs(math.Abs(real(roots[0]))-1e300)/1e300 > 1e-14 || math.Abs(real(roots[0])+real(roots[1]))/1e300 > 1e-14 {
+		t.Fatalf(`QuadraticRoots() returned %v. Expected 1e300 and -1e300`, roots)
+	}
+
+	// a = 0
+	roots = CreatePolynomial(0, 2, -4).QuadraticRoots()
+	if len(roots) != 1 || roots[0] != complex(2, 0) {
+		t.Fatalf(`QuadraticRoots() returned %v. Expected 2`, roots)
+	}
+
+	fmt.Println("Quadratic Hard Cases .. OK")
+}
+
+func TestDescartesIsolation(t *testing.T) {
+	// (x - 1)(x - 2)(x - 3)
+	poly := CreatePolynomial(1, -6, 11, -6)
+	intervals, err := poly.DescartesIsolation(0, 4)
+	expected := []Interval{{A: 0, B: 2, LeftOpen: true, RightOpen: true}, {A: 2, B: 2}, {A: 2, B: 4, LeftOpen: true, RightOpen: true}}
+	if err != nil || len(intervals) != len(expected) {
+		t.Fatalf(`DescartesIsolation() returned %v, %v. Expected %v`, intervals, err, expected)
+	}
+	for i := range expected {
+		if intervals[i] != expected[i] {
+			t.Fatalf(`DescartesIsolation() returned %v. Expected %v`, intervals, expected)
+		}
+	}
+	for _, root := range []float64{1, 2, 3} {
+		holding := 0
+		for _, iv := range intervals {
+			if iv.Contains(root) {
+				holding++
+			}
+		}
+		if holding != 1 {
+			t.Fatalf(`DescartesIsolation() returned %d intervals holding root %v`, holding, root)
+		}
+	}
+
+	// Intervals clipped to a bound that is not symmetric around the origin
+	intervals, err = poly.DescartesIsolation(1.5, 2.5)
+	if err != nil || len(intervals) != 1 || !intervals[0].Contains(2) {
+		t.Fatalf(`DescartesIsolation() returned %v, %v. Expected one interval holding 2`, intervals, err)
+	}
+
+	poly.IsolationMode = DescartesIsolation
+	roots, err := poly.RootsBisectionNewton()
+	if err != nil || len(roots) != 3 || roots[0] != 1 || roots[1] != 2 || roots[2] != 3 {
+		t.Fatalf(`RootsBisectionNewton() returned %v, %v. Expected [1 2 3]`, roots, err)
+	}
+
+	// Chebyshev polynomial T_30 has 30 roots in (-1, 1), all of them close
+	// to the ends of the interval
+	prev := CreatePolynomial(1)
+	cheb := CreatePolynomial(1, 0)
+	for n := 1; n < 30; n++ {
+		prev, cheb = cheb, cheb.Mult(CreatePolynomial(2, 0)).Sub(prev)
+	}
+
+	for _, bounds := range [][2]float64{{-1.5, 1.5}, {-1, 1}, {0, 2}, {-2, 0}} {
+		intervals, err = cheb.DescartesIsolation(bounds[0], bounds[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		count := 0
+		for k := 0; k < 30; k++ {
+			root := math.Cos(math.Pi * (float64(k) + 0.5) / 30.0)
+			if root < bounds[0] || root > bounds[1] {
+				continue
+			}
+			count++
+			holding := 0
+			for _, iv := range intervals {
+				if iv.Contains(root) {
+					holding++
+				}
+			}
+			if holding != 1 {
+				t.Fatalf(`DescartesIsolation(%v, %v) returned %d intervals holding root %v`, bounds[0], bounds[1], holding, root)
+			}
+		}
+		if len(intervals) != count {
+			t.Fatalf(`DescartesIsolation(%v, %v) returned %d intervals. Expected %d`, bounds[0], bounds[1], len(intervals), count)
+		}
+	}
+
+	cheb.IsolationMode = DescartesIsolation
+	roots, err = cheb.RootsBisectionNewton()
+	if err != nil || len(roots) != 30 {
+		t.Fatalf(`RootsBisectionNewton() returned %d roots, %v. Expected 30`, len(roots), err)
+	}
+
+	// Wilkinson's polynomial of degree 20, RootBounds() is far too wide to
+	// scale the polynomial by
+	wilkinson := CreatePolynomial(1)
+	for k := 1; k <= 20; k++ {
+		wilkinson = wilkinson.Mult(CreatePolynomial(1, -float64(k)))
+	}
+	lo, hi := wilkinson.RootBounds()
+	intervals, err = wilkinson.DescartesIsolation(lo, hi)
+	if err != nil || len(intervals) != 20 {
+		t.Fatalf(`DescartesIsolation() returned %v, %v. Expected 20 intervals`, intervals, err)
+	}
+
+	// The roots of the rounded polynomial move by up to about 0.01
+	wilkinson.IsolationMode = DescartesIsolation
+	roots, err = wilkinson.RootsWithin(lo, hi)
+	if err != nil || len(roots) != 20 {
+		t.Fatalf(`RootsWithin() returned %v, %v. Expected 20 roots`, roots, err)
+	}
+	for k, root := range roots {
+		if math.Abs(root-float64(k+1)) > 0.05 || !intervals[k].Contains(root) {
+			t.Fatalf(`RootsWithin() returned %v. Expected 1, 2, ..., 20`, roots)
+		}
+	}
+
+	fmt.Println("Descartes Isolation ... OK")
+}
+
+func TestRootCounting(t *testing.T) {
+	// (x - 1)(x - 2)(x - 3)
+	poly := CreatePolynomial(1, -6, 11, -6)
+
+	sequence := poly.SturmSequence()
+	if len(sequence) != 4 || sequence[0].Degree() != 3 || sequence[3].Degree() != 0 {
+		t.Fatalf(`SturmSequence() returned %v. Expected 4 polynomials of decreasing degree`, sequence)
+	}
+
+	windows := []struct {
+		a, b     float64
+		expected int
+	}{
+		{0, 4, 3}, {1.5, 4, 2}, {0, 1, 1}, {1, 1.5, 0}, {-10, 0, 0},
+	}
+	for _, w := range windows {
+		count, err := poly.CountRealRoots(w.a, w.b)
+		if err != nil || count != w.expected {
+			t.Fatalf(`CountRealRoots(%v, %v) returned %d, %v. Expected %d`, w.a, w.b, count, err, w.expected)
+		}
+		bound, err := poly.BudanFourierBound(w.a, w.b)
+		if err != nil || bound != w.expected {
+			t.Fatalf(`BudanFourierBound(%v, %v) returned %d, %v. Expected %d`, w.a, w.b, bound, err, w.expected)
+		}
+	}
+
+	if v := poly.DescartesSignVariations(); v != 3 {
+		t.Fatalf(`DescartesSignVariations() returned %d. Expected 3`, v)
+	}
+
+	// (x - 1)^2 (x + 1), the bounds count the double root twice
+	poly = CreatePolynomial(1, -1, -1, 1)
+	if count, _ := poly.CountRealRoots(-2, 2); count != 2 {
+		t.Fatalf(`CountRealRoots() returned %d. Expected 2`, count)
+	}
+	if bound, _ := poly.BudanFourierBound(0, 2); bound != 2 {
+		t.Fatalf(`BudanFourierBound() returned %d. Expected 2`, bound)
+	}
+	if v := poly.DescartesSignVariations(); v != 2 {
+		t.Fatalf(`DescartesSignVariations() returned %d. Expected 2`, v)
+	}
+
+	// x^2 + 1, the bounds may exceed the number of roots by an even number
+	poly = CreatePolynomial(1, 0, 1)
+	count, _ := poly.CountRealRoots(-1, 1)
+	bound, _ := poly.BudanFourierBound(-1, 1)
+	if count != 0 || bound != 2 {
+		t.Fatalf(`CountRealRoots() and BudanFourierBound() returned %d and %d. Expected 0 and 2`, count, bound)
+	}
+
+	if _, err := poly.CountRealRoots(1, -1); err == nil {
+		t.Fatalf(`CountRealRoots() accepted an invalid interval`)
+	}
+
+	fmt.Println("Root Counting ......... OK")
+}
+
+func TestArgumentPrinciple(t *testing.T) {
+	// Roots -2, -1, 0.5 and 1 +- i
+	poly := CreatePolynomial(1, 2).Mult(CreatePolynomial(1, 1)).Mult(CreatePolynomial(1, -0.5)).Mult(CreatePolynomial(1, -2, 2))
+
+	disks := []struct {
+		center   complex128
+		radius   float64
+		expected int
+	}{
+		{0, 1.2, 2}, {1, 1.1, 3}, {complex(1, 1), 0.1, 1}, {10, 1, 0}, {0, 100, 5},
+	}
+	for _, d := range disks {
+		count, err := poly.CountRootsInDisk(d.center, d.radius)
+		if err != nil || count != d.expected {
+			t.Fatalf(`CountRootsInDisk(%v, %v) returned %d, %v. Expected %d`, d.center, d.radius, count, err, d.expected)
+		}
+	}
+
+	count, err := poly.CountRootsInRectangle(complex(0, -0.5), complex(2, 2))
+	if err != nil || count != 2 {
+		t.Fatalf(`CountRootsInRectangle() returned %d, %v. Expected 2`, count, err)
+	}
+
+	count, err = poly.CountRootsInHalfPlane()
+	if err != nil || count != 2 {
+		t.Fatalf(`CountRootsInHalfPlane() returned %d, %v. Expected 2`, count, err)
+	}
+
+	// Roots on the contour
+	if _, err = poly.CountRootsInDisk(0, 1); err == nil {
+		t.Fatalf(`CountRootsInDisk() accepted a root on the contour`)
+	}
+	if _, err = CreatePolynomial(1, 0, 1).CountRootsInHalfPlane(); err == nil {
+		t.Fatalf(`CountRootsInHalfPlane() accepted a root on the imaginary axis`)
+	}
+
+	// Multiple roots are counted with multiplicity, (x + 1)^3 (x - 3)
+	count, err = CreatePolynomial(1, 3, 3, 1).Mult(CreatePolynomial(1, -3)).CountRootsInHalfPlane()
+	if err != nil || count != 3 {
+		t.Fatalf(`CountRootsInHalfPlane() returned %d, %v. Expected 3`, count, err)
+	}
+
+	// Chebyshev polynomial T_20 with closely spaced roots
+	prev := CreatePolynomial(1)
+	cheb := CreatePolynomial(1, 0)
+	for n := 1; n < 20; n++ {
+		prev, cheb = cheb, cheb.Mult(CreatePolynomial(2, 0)).Sub(prev)
+	}
+	expected := 0
+	for k := 0; k < 20; k++ {
+		if math.Abs(math.Cos(math.Pi*(float64(k)+0.5)/20.0)) < 0.5 {
+			expected++
+		}
+	}
+	count, err = cheb.CountRootsInDisk(0, 0.5)
+	if err != nil || count != expected {
+		t.Fatalf(`CountRootsInDisk() returned %d, %v. Expected %d`, count, err, expected)
+	}
+
+	fmt.Println("Argument Principle .... OK")
+}
+
+func TestHurwitzStability(t *testing.T) {
+	cases := []struct {
+		coeffs []float64
+		stable bool
+		rhp    int
+	}{
+		{[]float64{1, 2, 3, 1}, true, 0},
+		{[]float64{1, 1, 2, 8}, false, 2},
+		{[]float64{1, 1, 2, 2, 3}, false, 2},   // zero in the first column
+		{[]float64{1, 1, 1, 1}, false, 0},      // (s + 1)(s^2 + 1), row of zeros
+		{[]float64{1, -3, 2}, false, 2},        // (s - 1)(s - 2)
+		{[]float64{-1, -3, -2}, true, 0},       // negative leading coefficient
+		{[]float64{5}, true, 0},
+	}
+	for _, c := range cases {
+		stable, routh, rhp := CreatePolynomial(c.coeffs...).IsHurwitzStable()
+		if stable != c.stable || rhp != c.rhp {
+			t.Fatalf(`IsHurwitzStable() for %v returned %v, %d, %v. Expected %v, %d`, c.coeffs, stable, rhp, routh, c.stable, c.rhp)
+		}
+	}
+
+	_, routh, _ := CreatePolynomial(1, 1, 2, 8).IsHurwitzStable()
+	expected := [][]float64{{1, 2}, {1, 8}, {-6}, {8}}
+	for i := range expected {
+		for j := range expected[i] {
+			if routh[i][j] != expected[i][j] {
+				t.Fatalf(`IsHurwitzStable() returned the Routh array %v. Expected %v`, routh, expected)
+			}
+		}
+	}
+
+	// Agrees with the argument principle
+	polys := []*Polynomial{
+		CreatePolynomial(1, 2).Mult(CreatePolynomial(1, -1, 5)).Mult(CreatePolynomial(1, 3, 7)),
+		CreatePolynomial(1, 0.5).Mult(CreatePolynomial(1, 0.2, 9)).Mult(CreatePolynomial(1, -4)),
+		CreatePolynomial(2, 3, -1, 4, 6, 1),
+	}
+	for _, p := range polys {
+		_, _, rhp := p.IsHurwitzStable()
+		lhp, err := p.CountRootsInHalfPlane()
+		if err != nil || rhp != p.Degree()-lhp {
+			t.Fatalf(`IsHurwitzStable() returned %d roots in the right half plane. Expected %d`, rhp, p.Degree()-lhp)
+		}
+	}
+
+	fmt.Println("Hurwitz Stability ..... OK")
+}
+
+func TestSchurStability(t *testing.T) {
+	cases := []struct {
+		poly   *Polynomial
+		stable bool
+	}{
+		{CreatePolynomial(1, -0.5, 0.06), true},                                           // 0.2, 0.3
+		{CreatePolynomial(1, -2.5, 1), false},                                             // 2, 0.5
+		{CreatePolynomial(1, -1), false},                                                  // root on the circle
+		{CreatePolynomial(1, -0.5).Mult(CreatePolynomial(1, 0.9)).Mult(CreatePolynomial(1, 0, 0.25)), true},
+		{CreatePolynomial(1, -0.5).Mult(CreatePolynomial(1, 0.9)).Mult(CreatePolynomial(1, 0, 1.21)), false},
+		{CreatePolynomial(3), true},
+	}
+	for _, c := range cases {
+		stable, jury := c.poly.IsSchurStable()
+		if stable != c.stable {
+			t.Fatalf(`IsSchurStable() for %v returned %v, %v. Expected %v`, c.poly, stable, jury, c.stable)
+		}
+	}
+
+	_, jury := CreatePolynomial(1, -0.5, 0.06).IsSchurStable()
+	if len(jury) != 3 || len(jury[1]) != 2 || math.Abs(jury[1][1]-(1-0.06*0.06)) > 1e-15 {
+		t.Fatalf(`IsSchurStable() returned the Jury table %v`, jury)
+	}
+
+	fmt.Println("Schur Stability ....... OK")
+}
+
+func TestExtrema(t *testing.T) {
+	// p = x^4 - 2x^2, minima at -1 and 1, maximum at 0
+	poly := CreatePolynomial(1, 0, -2, 0, 0)
+
+	points, err := poly.CriticalPoints()
+	expected := []CriticalPoint{{-1, -1, LocalMinimum}, {0, 0, LocalMaximum}, {1, -1, LocalMinimum}}
+	if err != nil || len(points) != len(expected) {
+		t.Fatalf(`CriticalPoints() returned %v, %v. Expected %v`, points, err, expected)
+	}
+	for i := range expected {
+		if Round(points[i].X) != expected[i].X || Round(points[i].Y) != expected[i].Y || points[i].Kind != expected[i].Kind {
+			t.Fatalf(`CriticalPoints() returned %v. Expected %v`, points, expected)
+		}
+	}
+
+	minima, _ := poly.LocalMinima()
+	maxima, _ := poly.LocalMaxima()
+	if len(minima) != 2 || len(maxima) != 1 || Round(maxima[0].X) != 0 {
+		t.Fatalf(`LocalMinima() and LocalMaxima() returned %v and %v`, minima, maxima)
+	}
+
+	inflections, err := poly.InflectionPoints()
+	x := 1.0 / math.Sqrt(3.0)
+	if err != nil || len(inflections) != 2 || math.Abs(inflections[0].X+x) > 1e-9 || math.Abs(inflections[1].X-x) > 1e-9 {
+		t.Fatalf(`InflectionPoints() returned %v, %v. Expected -+%v`, inflections, err, x)
+	}
+
+	// Higher order derivative test, x^4 has a minimum and x^3 a stationary
+	// inflection point at 0
+	points, _ = CreatePolynomial(1, 0, 0, 0, 0).CriticalPoints()
+	if len(points) != 1 || points[0].Kind != LocalMinimum {
+		t.Fatalf(`CriticalPoints() returned %v. Expected a minimum at 0`, points)
+	}
+	points, _ = CreatePolynomial(-1, 0, 0, 0, 0, 0, 0).CriticalPoints()
+	if len(points) != 1 || points[0].Kind != LocalMaximum {
+		t.Fatalf(`CriticalPoints() returned %v. Expected a maximum at 0`, points)
+	}
+	points, _ = CreatePolynomial(1, 0, 0, 0).CriticalPoints()
+	if len(points) != 1 || points[0].Kind != StationaryInflection {
+		t.Fatalf(`CriticalPoints() returned %v. Expected a stationary inflection point at 0`, points)
+	}
+	inflections, _ = CreatePolynomial(1, 0, 0, 0).InflectionPoints()
+	if len(inflections) != 1 || inflections[0].X != 0 {
+		t.Fatalf(`InflectionPoints() returned %v. Expected 0`, inflections)
+	}
+
+	// Global extrema on an interval, at a critical point and at an end
+	min, max, err := poly.GlobalExtremaOn(-0.5, 2)
+	if err != nil || Round(min.X) != 1 || Round(min.Y) != -1 || max.X != 2 || max.Y != 8 {
+		t.Fatalf(`GlobalExtremaOn() returned %v, %v, %v. Expected {1 -1} and {2 8}`, min, max, err)
+	}
+
+	min, max, _ = CreatePolynomial(3).GlobalExtremaOn(0, 1)
+	if min.Y != 3 || max.Y != 3 {
+		t.Fatalf(`GlobalExtremaOn() returned %v, %v. Expected 3`, min, max)
+	}
+
+	if _, err = CreatePolynomial(3).CriticalPoints(); err == nil {
+		t.Fatalf(`CriticalPoints() of a constant polynomial did not error`)
+	}
+
+	// p' = (x-1)(x-1.0001) has a maximum and a minimum 1e-4 apart
+	close := CreatePolynomial(1.0/3, -2.0001/2, 1.0001, 0)
+	points, err = close.CriticalPoints()
+	if err != nil || len(points) != 2 || points[0].Kind != LocalMaximum || points[1].Kind != LocalMinimum {
+		t.Fatalf(`CriticalPoints() returned %v, %v for critical points 1e-4 apart`, points, err)
+	}
+
+	fmt.Println("Extrema ............... OK")
+}
+
+func TestInequality(t *testing.T) {
+	inf := math.Inf(1)
+
+	// (x - 1)^2 (x + 2), the double root at 1 does not change the sign
+	poly := CreatePolynomial(1, 0, -3, 2)
+
+	chart, err := poly.SignChart()
+	signs := []int{-1, 0, 1, 0, 1}
+	if err != nil || len(chart) != len(signs) {
+		t.Fatalf(`SignChart() returned %v, %v`, chart, err)
+	}
+	for i, s := range signs {
+		if chart[i].Sign != s {
+			t.Fatalf(`SignChart() returned %v. Expected the signs %v`, chart, signs)
+		}
+	}
+
+	cases := []struct {
+		op       InequalityOp
+		expected []Interval
+	}{
+		{Greater, []Interval{{A: -2, B: 1, LeftOpen: true, RightOpen: true}, {A: 1, B: inf, LeftOpen: true, RightOpen: true}}},
+		{GreaterOrEqual, []Interval{{A: -2, B: inf, RightOpen: true}}},
+		{Less, []Interval{{A: -inf, B: -2, LeftOpen: true, RightOpen: true}}},
+		{LessOrEqual, []Interval{{A: -inf, B: -2, LeftOpen: true}, {A: 1, B: 1}}},
+	}
+	for _, c := range cases {
+		intervals, err := poly.SolveInequality(c.op)
+		if err != nil || len(intervals) != len(c.expected) {
+			t.Fatalf(`SolveInequality(%v) returned %v, %v. Expected %v`, c.op, intervals, err, c.expected)
+		}
+		for i := range c.expected {
+			got := intervals[i]
+			got.A, got.B = Round(got.A), Round(got.B)
+			if got != c.expected[i] {
+				t.Fatalf(`SolveInequality(%v) returned %v. Expected %v`, c.op, intervals, c.expected)
+			}
+		}
+	}
+
+	// No real roots
+	intervals, _ := CreatePolynomial(1, 0, 1).SolveInequality(Greater)
+	if len(intervals) != 1 || !intervals[0].Contains(-1e300) || !intervals[0].Contains(1e300) {
+		t.Fatalf(`SolveInequality() returned %v. Expected the whole real line`, intervals)
+	}
+	intervals, _ = CreatePolynomial(-1, 0, -1).SolveInequality(GreaterOrEqual)
+	if len(intervals) != 0 {
+		t.Fatalf(`SolveInequality() returned %v. Expected no solutions`, intervals)
+	}
+
+	// Zero polynomial
+	intervals, _ = CreatePolynomial().SolveInequality(LessOrEqual)
+	if len(intervals) != 1 || intervals[0].String() != "(-Inf, +Inf)" {
+		t.Fatalf(`SolveInequality() returned %v. Expected the whole real line`, intervals)
+	}
+	intervals, _ = CreatePolynomial().SolveInequality(Less)
+	if len(intervals) != 0 {
+		t.Fatalf(`SolveInequality() returned %v. Expected no solutions`, intervals)
+	}
+
+	interval := Interval{A: 0, B: 1, LeftOpen: true}
+	if interval.Contains(0) || !interval.Contains(1) || interval.String() != "(0, 1]" || interval.IsEmpty() {
+		t.Fatalf(`Interval %v has wrong open ends`, interval)
+	}
+
+	fmt.Println("Inequality ............ OK")
+}
+
+func TestNthDerivative(t *testing.T) {
+	poly := CreatePolynomial(1, 1, 0, -1, -1)
+
+	second := poly.NthDerivative(2)
+	if second.Degree() != 2 || second.coeffs[0] != 12 || second.coeffs[1] != 6 || second.coeffs[2] != 0 {
+		t.Fatalf(`NthDerivative(2) failed. Expected coeffs: [12 6 0]. Received coeffs: %v`, second.coeffs)
+	}
+
+	for n := 0; n <= 6; n++ {
+		expected := poly
+		for i := 0; i < n; i++ {
+			expected = expected.Derivative()
+		}
+		deriv := poly.NthDerivative(n)
+		if deriv.String() != expected.String() {
+			t.Fatalf(`NthDerivative(%d) returned %v. Expected %v`, n, deriv, expected)
+		}
+	}
+
+	if !poly.NthDerivative(5).IsZero() {
+		t.Fatalf(`NthDerivative(5) of a quartic is not zero`)
+	}
+
+	fmt.Println("Nth Derivative ........ OK")
+}
+
+func TestIntegral(t *testing.T) {
+	// 3x^2 + 2x + 1 integrates to x^3 + x^2 + x + C
+	poly := CreatePolynomial(3, 2, 1)
+	integral := poly.Integral(5)
+	if integral.Degree() != 3 || integral.coeffs[0] != 1 || integral.coeffs[1] != 1 || integral.coeffs[2] != 1 || integral.coeffs[3] != 5 {
+		t.Fatalf(`Integral() failed. Expected coeffs: [1 1 1 5]. Received coeffs: %v`, integral.coeffs)
+	}
+
+	deriv := CreatePolynomial(4, -2, 7, 1, 3).Integral(0).Derivative()
+	if deriv.String() != CreatePolynomial(4, -2, 7, 1, 3).String() {
+		t.Fatalf(`Derivative() of Integral() returned %v`, deriv)
+	}
+
+	if value := poly.DefiniteIntegral(0, 2); value != 14 {
+		t.Fatalf(`DefiniteIntegral() returned %v. Expected 14`, value)
+	}
+	if value := poly.DefiniteIntegral(2, 0); value != -14 {
+		t.Fatalf(`DefiniteIntegral() returned %v. Expected -14`, value)
+	}
+
+	// Odd polynomial over a symmetric interval
+	if value := CreatePolynomial(1, 0, -3, 0).DefiniteIntegral(-1.5, 1.5); value != 0 {
+		t.Fatalf(`DefiniteIntegral() returned %v. Expected 0`, value)
+	}
+
+	// High degree, x^50 from 0 to 1
+	coeffs := make([]float64, 51)
+	coeffs[0] = 1
+	if value := CreatePolynomial(coeffs...).DefiniteIntegral(0, 1); math.Abs(value-1.0/51.0) > 1e-17 {
+		t.Fatalf(`DefiniteIntegral() returned %v. Expected %v`, value, 1.0/51.0)
+	}
+
+	// Short interval far from the origin, x^2 from 1 to 1 + h
+	a := 1.0
+	b := a + 1e-8
+	h := b - a
+	expected := h * (1 + h + h*h/3)
+	if value := CreatePolynomial(1, 0, 0).DefiniteIntegral(a, b); math.Abs(value-expected)/expected > 1e-15 {
+		t.Fatalf(`DefiniteIntegral() returned %v. Expected %v`, value, expected)
+	}
+
+	fmt.Println("Integral .............. OK")
+}
+
+func TestTransformations(t *testing.T) {
+	poly := CreatePolynomial(2, -3, 0, 5, 1)
+
+	// (x^2 + 1) o (x - 1) = x^2 - 2x + 2
+	composed := CreatePolynomial(1, 0, 1).Compose(CreatePolynomial(1, -1))
+	if composed.String() != CreatePolynomial(1, -2, 2).String() {
+		t.Fatalf(`Compose() returned %v. Expected x^2 - 2x + 2`, composed)
+	}
+
+	// Composition with a constant
+	composed = poly.Compose(CreatePolynomial(2))
+	if composed.Degree() != 0 || composed.coeffs[0] != poly.At(2) {
+		t.Fatalf(`Compose() returned %v. Expected %v`, composed, poly.At(2))
+	}
+
+	// Composition with a quadratic agrees with evaluating twice
+	q := CreatePolynomial(1, -1, 3)
+	composed = poly.Compose(q)
+	if composed.Degree() != 8 || composed.At(1.3) != poly.At(q.At(1.3)) {
+		t.Fatalf(`Compose() returned %v`, composed)
+	}
+
+	for _, c := range []float64{-2, 0, 0.5, 3} {
+		shifted := poly.TaylorShift(c)
+		expected := poly.Compose(CreatePolynomial(1, c))
+		if shifted.String() != expected.String() {
+			t.Fatalf(`TaylorShift(%v) returned %v. Expected %v`, c, shifted, expected)
+		}
+	}
+
+	scaled := CreatePolynomial(1, 1, 1).ScaleVariable(2)
+	if scaled.String() != CreatePolynomial(4, 2, 1).String() {
+		t.Fatalf(`ScaleVariable() returned %v. Expected 4x^2 + 2x + 1`, scaled)
+	}
+
+	// x^3 p(1/x) for p = x^3 + 2x + 5
+	reciprocal := CreatePolynomial(1, 0, 2, 5).Reciprocal()
+	if reciprocal.Degree() != 3 || reciprocal.coeffs[0] != 5 || reciprocal.coeffs[1] != 2 || reciprocal.coeffs[2] != 0 || reciprocal.coeffs[3] != 1 {
+		t.Fatalf(`Reciprocal() returned %v. Expected 5x^3 + 2x^2 + 1`, reciprocal)
+	}
+	if CreatePolynomial(1, 2, 0).Reciprocal().Degree() != 1 {
+		t.Fatalf(`Reciprocal() of a polynomial with a root at zero did not lower the degree`)
+	}
+
+	fmt.Println("Transformations ....... OK")
+}
+
+func TestPow(t *testing.T) {
+	// (x - 1)^5
+	pow := CreatePolynomial(1, -1).Pow(5)
+	expected := []float64{1, -5, 10, -10, 5, -1}
+	if pow.Degree() != 5 {
+		t.Fatalf(`Pow() returned %v. Expected coeffs %v`, pow, expected)
+	}
+	for i, c := range expected {
+		if pow.coeffs[i] != c {
+			t.Fatalf(`Pow() returned %v. Expected coeffs %v`, pow, expected)
+		}
+	}
+
+	poly := CreatePolynomial(2, -1, 3)
+	for n := 0; n <= 7; n++ {
+		looped := CreatePolynomial(1)
+		for i := 0; i < n; i++ {
+			looped = looped.Mult(poly)
+		}
+		if pow := poly.Pow(n); pow.String() != looped.String() {
+			t.Fatalf(`Pow(%d) returned %v. Expected %v`, n, pow, looped)
+		}
+	}
+
+	// x^n mod (x^2 + 1) cycles through 1, x, -1, -x
+	m := CreatePolynomial(1, 0, 1)
+	x := CreatePolynomial(1, 0)
+	cycle := []string{CreatePolynomial(1).String(), x.String(), CreatePolynomial(-1).String(), CreatePolynomial(-1, 0).String()}
+	for n := 0; n <= 9; n++ {
+		if r := x.PowMod(n, m); r.String() != cycle[n%4] {
+			t.Fatalf(`PowMod(%d) returned %v. Expected %v`, n, r, cycle[n%4])
+		}
+	}
+
+	// Agrees with the remainder of the full power
+	m = CreatePolynomial(1, -2, 0, 3)
+	_, expectedRem := poly.Pow(6).EuclideanDiv(m)
+	rem := poly.PowMod(6, m)
+	if rem.Degree() != expectedRem.Degree() {
+		t.Fatalf(`PowMod() returned %v. Expected %v`, rem, expectedRem)
+	}
+	for i := range rem.coeffs {
+		if math.Abs(rem.coeffs[i]-expectedRem.coeffs[i]) > 1e-9*math.Abs(expectedRem.coeffs[i]) {
+			t.Fatalf(`PowMod() returned %v. Expected %v`, rem, expectedRem)
+		}
+	}
+
+	if !poly.PowMod(3, CreatePolynomial(5)).IsZero() {
+		t.Fatalf(`PowMod() by a constant is not zero`)
+	}
+
+	fmt.Println("Pow ................... OK")
+}
+
+func TestFFTMult(t *testing.T) {
+	schoolbook := func(x []float64, y []float64) []float64 {
+		prod := make([]float64, len(x)+len(y)-1)
+		for i := range x {
+			for j := range y {
+				prod[i+j] += x[i] * y[j]
+			}
+		}
+		return prod
+	}
+
+	check := func(x []float64, y []float64) {
+		prod, ok := fftMult(x, y, true)
+		if !ok {
+			t.Fatalf(`fftMult() rejected a product of coefficients of similar magnitude`)
+		}
+		expected := schoolbook(x, y)
+		if len(prod) != len(expected) {
+			t.Fatalf(`fftMult() returned %d coefficients. Expected %d`, len(prod), len(expected))
+		}
+		for i := range expected {
+			if math.Abs(prod[i]-expected[i]) > 1e-10*math.Abs(expected[i]) {
+				t.Fatalf(`fftMult() returned %v for coefficient %d. Expected %v`, prod[i], i, expected[i])
+			}
+		}
+	}
+
+	// Coefficients of similar magnitude, the FFT is accurate enough
+	a := make([]float64, 300)
+	b := make([]float64, 257)
+	for i := range a {
+		a[i] = 1.0 + 0.5*math.Sin(float64(i))
+	}
+	for i := range b {
+		b[i] = 1.0 + 0.5*math.Cos(float64(3*i))
+	}
+	check(a, b)
+
+	// Powers of x are factored out before the transform
+	c := append(append([]float64{}, a...), make([]float64, 70)...)
+	check(c, b)
+
+	// The coefficients near the tiny leading terms are recomputed directly
+	d := append([]float64{}, a...)
+	e := append([]float64{}, b...)
+	d[0], e[0] = 1e-9, 1e-9
+	check(d, e)
+
+	// Binomial coefficients range over many orders of magnitude, the FFT
+	// is not used
+	binomial := CreatePolynomial(1, -1).Pow(100)
+	if _, ok := fftMult(binomial.coeffs, binomial.coeffs, true); ok {
+		t.Fatalf(`fftMult() accepted a product of binomial coefficients`)
+	}
+
+	// Long enough for Mult to use the FFT
+	long := make([]float64, 2*FFTMultThreshold)
+	for i := range long {
+		long[i] = 1.0 + float64(i%7)/7.0
+	}
+	prod := CreatePolynomial(long...).Mult(CreatePolynomial(long...))
+	expected := schoolbook(long, long)
+	for i := range expected {
+		if math.Abs(prod.coeffs[i]-expected[i]) > 1e-10*math.Abs(expected[i]) {
+			t.Fatalf(`Mult() returned %v for coefficient %d. Expected %v`, prod.coeffs[i], i, expected[i])
+		}
+	}
+
+	fmt.Println("FFT Mult .............. OK")
+}
+
+func TestFastDivision(t *testing.T) {
+	// Divisor with a dominant leading coefficient, so that the quotient is
+	// well conditioned
+	a := make([]float64, 700)
+	for i := range a {
+		a[i] = 1.0 + float64(i%7)/7.0
+	}
+	b := make([]float64, 300)
+	copy(b, a)
+	b[0] = 1000.0
+
+	q1, r1 := schoolbookDiv(a, b)
+	q2, r2, ok := newtonDiv(a, b)
+	if !ok {
+		t.Fatalf(`newtonDiv() rejected the FFT products`)
+	}
+	if len(q1) != len(q2) || len(r1) != len(r2) {
+		t.Fatalf(`newtonDiv() returned %d and %d coefficients. Expected %d and %d`, len(q2), len(r2), len(q1), len(r1))
+	}
+	for i := range q1 {
+		if math.Abs(q1[i]-q2[i]) > 1e-10*(1+math.Abs(q1[i])) {
+			t.Fatalf(`newtonDiv() returned %v for quotient coefficient %d. Expected %v`, q2[i], i, q1[i])
+		}
+	}
+	for i := range r1 {
+		if math.Abs(r1[i]-r2[i]) > 1e-10*(1+math.Abs(r1[i])) {
+			t.Fatalf(`newtonDiv() returned %v for remainder coefficient %d. Expected %v`, r2[i], i, r1[i])
+		}
+	}
+
+	// With the threshold lowered, EuclideanDiv uses Newton's iteration for
+	// these sizes and returns the same quotient, p = q d + r
+	defer func(threshold int) { FastDivThreshold = threshold }(FastDivThreshold)
+	FastDivThreshold = 256
+
+	p := CreatePolynomial(a...)
+	d := CreatePolynomial(b...)
+	quot, rem := p.EuclideanDiv(d)
+	if quot.Degree() != 400 || rem.Degree() >= d.Degree() {
+		t.Fatalf(`EuclideanDiv() returned a quotient of degree %d and a remainder of degree %d`, quot.Degree(), rem.Degree())
+	}
+	for i := range q2 {
+		if quot.coeffs[i] != q2[i] {
+			t.Fatalf(`EuclideanDiv() returned %v for quotient coefficient %d. Expected %v from newtonDiv()`, quot.coeffs[i], i, q2[i])
+		}
+	}
+	back := quot.Mult(d).Add(rem)
+	for i := range a {
+		if math.Abs(back.coeffs[i]-a[i]) > 1e-9 {
+			t.Fatalf(`EuclideanDiv() returned q and r with q d + r = %v for coefficient %d. Expected %v`, back.coeffs[i], i, a[i])
+		}
+	}
+
+	// Series inverse of 1 - x is 1 + x + x^2 + ...
+	inv := seriesInverse([]float64{1, -1}, 10)
+	for i, c := range inv {
+		if c != 1 {
+			t.Fatalf(`seriesInverse() returned %v for coefficient %d. Expected 1`, c, i)
+		}
+	}
+
+	fmt.Println("Fast Division ......... OK")
+}
+
+func TestMultipoint(t *testing.T) {
+	coeffs := make([]float64, 40)
+	for i := range coeffs {
+		coeffs[i] = math.Sin(float64(i))
+	}
+	poly := CreatePolynomial(coeffs...)
+
+	// 100 points for subproduct trees, in blocks of 40, 40 and 20 points
+	xs := make([]float64, 100)
+	for i := range xs {
+		xs[i] = 0.5 * math.Cos(float64(3*i))
+	}
+
+	defer func(threshold int) { MultipointThreshold = threshold }(MultipointThreshold)
+	MultipointThreshold = 32
+
+	tree := subproductTree(xs[:40])
+	values := make([]float64, 40)
+	if !remainderTree(poly.coeffs, tree, xs[:40], values) {
+		t.Fatalf(`remainderTree() rejected the values`)
+	}
+
+	serial := poly.EvaluateMany(xs)
+	parallel := poly.EvaluateManyParallel(xs, 3)
+	for i, x := range xs {
+		if math.Abs(serial[i]-poly.At(x)) > 1e-10 {
+			t.Fatalf(`EvaluateMany() returned %v at %v. Expected %v`, serial[i], x, poly.At(x))
+		}
+		if parallel[i] != serial[i] {
+			t.Fatalf(`EvaluateManyParallel() returned %v at %v. Expected %v`, parallel[i], x, serial[i])
+		}
+	}
+
+	// Fewer points than coefficients in a single block, and fewer than
+	// MultipointThreshold for Horner's method
+	few := poly.EvaluateMany(xs[:35])
+	short := poly.EvaluateMany(xs[:20])
+	for i, x := range xs[:20] {
+		if short[i] != poly.At(x) {
+			t.Fatalf(`EvaluateMany() returned %v at %v. Expected %v`, short[i], x, poly.At(x))
+		}
+	}
+	for i, x := range xs[:35] {
+		if math.Abs(few[i]-poly.At(x)) > 1e-10 {
+			t.Fatalf(`EvaluateMany() returned %v at %v. Expected %v`, few[i], x, poly.At(x))
+		}
+	}
+
+	// Interpolation recovers the polynomial from as many values as it has
+	// coefficients
+	small := CreatePolynomial(2, -3, 0, 1, 5, -1)
+	points := []float64{-2, -1, -0.5, 0.5, 1, 2}
+	interp, err := InterpolateFast(points, small.EvaluateMany(points))
+	if err != nil {
+		t.Fatalf(`InterpolateFast() returned an error: %v`, err)
+	}
+	if len(interp.coeffs) != len(small.coeffs) {
+		t.Fatalf(`InterpolateFast() returned %v. Expected %v`, interp, small)
+	}
+	for i := range small.coeffs {
+		if math.Abs(interp.coeffs[i]-small.coeffs[i]) > 1e-9 {
+			t.Fatalf(`InterpolateFast() returned %v. Expected %v`, interp, small)
+		}
+	}
+
+	// The sums up the tree cancel at 40 Chebyshev points, and the samples
+	// are interpolated with divided differences instead
+	cheb := make([]float64, 40)
+	exps := make([]float64, 40)
+	for i := range cheb {
+		cheb[i] = math.Cos(math.Pi * (float64(i) + 0.5) / 40.0)
+		exps[i] = math.Exp(cheb[i])
+	}
+	interp, err = InterpolateFast(cheb, exps)
+	if err != nil {
+		t.Fatalf(`InterpolateFast() returned an error: %v`, err)
+	}
+	for i, x := range cheb {
+		if math.Abs(interp.At(x)-exps[i]) > 1e-10 {
+			t.Fatalf(`InterpolateFast() returned %v at %v. Expected %v`, interp.At(x), x, exps[i])
+		}
+	}
+
+	if _, err := InterpolateFast([]float64{1, 2, 1}, []float64{0, 1, 2}); err == nil {
+		t.Fatalf(`InterpolateFast() accepted repeated points`)
+	}
+	if _, err := InterpolateFast([]float64{1, 2}, []float64{0}); err == nil {
+		t.Fatalf(`InterpolateFast() accepted values of the wrong length`)
+	}
+
+	fmt.Println("Multipoint ............ OK")
+}
+
+func TestInterpolation(t *testing.T) {
+	poly := CreatePolynomial(0.5, -2, 0, 3, 1)
+	xs := []float64{-2, -1, 0.5, 1.5, 3}
+	ys := make([]float64, len(xs))
+	dys := make([]float64, len(xs))
+	deriv := poly.Derivative()
+	for i, x := range xs {
+		ys[i] = poly.At(x)
+		dys[i] = deriv.At(x)
+	}
+
+	expect := func(name string, got *Polynomial, want *Polynomial) {
+		if len(got.coeffs) != len(want.coeffs) {
+			t.Fatalf(`%s() returned %v. Expected %v`, name, got, want)
+		}
+		for i := range want.coeffs {
+			if math.Abs(got.coeffs[i]-want.coeffs[i]) > 1e-9 {
+				t.Fatalf(`%s() returned %v. Expected %v`, name, got, want)
+			}
+		}
+	}
+
+	lagrange, err := InterpolateLagrange(xs, ys)
+	if err != nil {
+		t.Fatalf(`InterpolateLagrange() returned an error: %v`, err)
+	}
+	expect("InterpolateLagrange", lagrange, poly)
+
+	newton, err := InterpolateNewton(xs, ys)
+	if err != nil {
+		t.Fatalf(`InterpolateNewton() returned an error: %v`, err)
+	}
+	expect("InterpolateNewton", newton, poly)
+
+	// Two points with values and derivatives determine a cubic
+	cubic := CreatePolynomial(1, -2, 3, -4)
+	hermite, err := InterpolateHermite([]float64{-1, 2}, []float64{cubic.At(-1), cubic.At(2)}, []float64{cubic.Derivative().At(-1), cubic.Derivative().At(2)})
+	if err != nil {
+		t.Fatalf(`InterpolateHermite() returned an error: %v`, err)
+	}
+	expect("InterpolateHermite", hermite, cubic)
+
+	hermite, err = InterpolateHermite(xs, ys, dys)
+	if err != nil {
+		t.Fatalf(`InterpolateHermite() returned an error: %v`, err)
+	}
+	expect("InterpolateHermite", hermite, poly)
+
+	bary, err := CreateBarycentric(xs, ys)
+	if err != nil {
+		t.Fatalf(`CreateBarycentric() returned an error: %v`, err)
+	}
+	for _, x := range []float64{-2.5, -1, 0, 0.7, 2, 3} {
+		if math.Abs(bary.At(x)-poly.At(x)) > 1e-9 {
+			t.Fatalf(`Barycentric.At() returned %v at %v. Expected %v`, bary.At(x), x, poly.At(x))
+		}
+	}
+
+	// Barycentric evaluation stays accurate for Runge's function at many
+	// Chebyshev points, where the monomial coefficients are huge
+	n := 60
+	cheb := make([]float64, n)
+	runge := make([]float64, n)
+	for i := range cheb {
+		cheb[i] = math.Cos(math.Pi * (float64(i) + 0.5) / float64(n))
+		runge[i] = 1 / (1 + 25*cheb[i]*cheb[i])
+	}
+	bary, _ = CreateBarycentric(cheb, runge)
+	if got := bary.At(0.3); math.Abs(got-1/(1+25*0.09)) > 1e-3 {
+		t.Fatalf(`Barycentric.At() returned %v for Runge's function at 0.3. Expected %v`, got, 1/(1+25*0.09))
+	}
+
+	// The products of the differences of 200 points on [0, 1000] overflow
+	// without scaling
+	n = 200
+	wide := make([]float64, n)
+	sines := make([]float64, n)
+	for i := range wide {
+		wide[i] = 500 + 500*math.Cos(math.Pi*(float64(i)+0.5)/float64(n))
+		sines[i] = math.Sin(wide[i] / 300)
+	}
+	bary, _ = CreateBarycentric(wide, sines)
+	if got := bary.At(123.4); !(math.Abs(got-math.Sin(123.4/300)) <= 1e-12) {
+		t.Fatalf(`Barycentric.At() returned %v at 123.4. Expected %v`, got, math.Sin(123.4/300))
+	}
+
+	if _, err := InterpolateNewton([]float64{0, 1, 0}, []float64{1, 2, 3}); err == nil {
+		t.Fatalf(`InterpolateNewton() accepted repeated points`)
+	}
+	if _, err := InterpolateHermite(xs, ys, dys[:2]); err == nil {
+		t.Fatalf(`InterpolateHermite() accepted derivatives of the wrong length`)
+	}
+
+	fmt.Println("Interpolation ......... OK")
+}
+
+func TestFit(t *testing.T) {
+	// Exact data far from the origin is recovered
+	poly := CreatePolynomial(0.01, -3, 2, 7)
+	xs := make([]float64, 30)
+	ys := make([]float64, 30)
+	for i := range xs {
+		xs[i] = 100 + float64(i)/3
+		ys[i] = poly.At(xs[i])
+	}
+	fit, diag, err := Fit(xs, ys, 3, nil)
+	if err != nil {
+		t.Fatalf(`Fit() returned an error: %v`, err)
+	}
+	for i, x := range xs {
+		if math.Abs(fit.At(x)-ys[i]) > 1e-6*math.Abs(ys[i]) {
+			t.Fatalf(`Fit() returned %v with value %v at %v. Expected %v`, fit, fit.At(x), x, ys[i])
+		}
+	}
+	if math.Abs(diag.RSquared-1) > 1e-12 {
+		t.Fatalf(`Fit() returned R^2 = %v. Expected 1`, diag.RSquared)
+	}
+	if diag.ConditionNumber < 1 || diag.ConditionNumber > 100 {
+		t.Fatalf(`Fit() returned condition number %v for scaled points`, diag.ConditionNumber)
+	}
+
+	// Line through alternating residuals +-1, where the variance of the
+	// slope is sigma^2 / sum (x - mean)^2
+	xs = []float64{0, 1, 2, 3, 4, 5}
+	ys = []float64{1, 1, 5, 5, 9, 9}
+	fit, diag, err = Fit(xs, ys, 1, nil)
+	if err != nil {
+		t.Fatalf(`Fit() returned an error: %v`, err)
+	}
+	ssRes := 0.0
+	for i, r := range diag.Residuals {
+		ssRes += r * r
+		if math.Abs(ys[i]-fit.At(xs[i])-r) > 1e-9 {
+			t.Fatalf(`Fit() returned residual %v at %v. Expected %v`, r, xs[i], ys[i]-fit.At(xs[i]))
+		}
+	}
+	varSlope := ssRes / 4 / 17.5
+	if math.Abs(diag.Covariance.At(0, 0)-varSlope) > 1e-9 {
+		t.Fatalf(`Fit() returned slope variance %v. Expected %v`, diag.Covariance.At(0, 0), varSlope)
+	}
+	if diag.RSquared <= 0.9 || diag.RSquared >= 1 {
+		t.Fatalf(`Fit() returned R^2 = %v`, diag.RSquared)
+	}
+
+	// A point of weight zero does not change the degrees of freedom
+	_, diag, err = Fit(append(xs, 6), append(ys, 100), 1, &FitOptions{Weights: []float64{1, 1, 1, 1, 1, 1, 0}})
+	if err != nil {
+		t.Fatalf(`Fit() returned an error: %v`, err)
+	}
+	if math.Abs(diag.Covariance.At(0, 0)-varSlope) > 1e-9 {
+		t.Fatalf(`Fit() returned slope variance %v with a zero weight. Expected %v`, diag.Covariance.At(0, 0), varSlope)
+	}
+
+	// A point with weight zero is ignored
+	xs = []float64{0, 1, 2, 3}
+	ys = []float64{1, 3, 100, 7}
+	fit, _, err = Fit(xs, ys, 1, &FitOptions{Weights: []float64{1, 1, 0, 1}})
+	if err != nil {
+		t.Fatalf(`Fit() returned an error: %v`, err)
+	}
+	if math.Abs(fit.coeffs[0]-2) > 1e-9 || math.Abs(fit.coeffs[1]-1) > 1e-9 {
+		t.Fatalf(`Fit() returned %v. Expected 2x + 1`, fit)
+	}
+
+	// As many points as coefficients interpolate, without a covariance
+	_, diag, err = Fit([]float64{1, 2, 3}, []float64{1, 4, 9}, 2, nil)
+	if err != nil || diag.Covariance != nil {
+		t.Fatalf(`Fit() returned %v, %v for interpolation`, diag, err)
+	}
+
+	if _, _, err := Fit([]float64{1, 1, 1}, []float64{1, 2, 3}, 1, nil); err == nil {
+		t.Fatalf(`Fit() accepted a single distinct point for a line`)
+	}
+	if _, _, err := Fit([]float64{1, 2}, []float64{1, 2}, 2, nil); err == nil {
+		t.Fatalf(`Fit() accepted too few points`)
+	}
+
+	fmt.Println("Fit ................... OK")
+}
+
+func TestConstrainedFit(t *testing.T) {
+	xs := make([]float64, 41)
+	ys := make([]float64, 41)
+	for i := range xs {
+		xs[i] = float64(i) / 4
+		ys[i] = xs[i] + 2*math.Sin(xs[i])
+	}
+
+	// Zero constant term, the slope of a line through the origin is
+	// sum x y / sum x^2
+	line, _, err := Fit(xs, ys, 1, &FitOptions{Constraints: []FitConstraint{{X: 0, Value: 0}}})
+	if err != nil {
+		t.Fatalf(`Fit() returned an error: %v`, err)
+	}
+	sxy, sxx := 0.0, 0.0
+	for i, x := range xs {
+		sxy += x * ys[i]
+		sxx += x * x
+	}
+	if len(line.coeffs) != 2 || math.Abs(line.coeffs[0]-sxy/sxx) > 1e-9 || math.Abs(line.coeffs[1]) > 1e-9 {
+		t.Fatalf(`Fit() returned %v. Expected %vx`, line, sxy/sxx)
+	}
+
+	// Value and slope fixed at both ends
+	cons := []FitConstraint{{X: 0, Value: 0}, {X: 10, Value: 10}, {X: 0, Derivative: 1, Value: 1}, {X: 10, Derivative: 1, Value: 1}}
+	fixed, diag, err := Fit(xs, ys, 7, &FitOptions{Constraints: cons})
+	if err != nil {
+		t.Fatalf(`Fit() returned an error: %v`, err)
+	}
+	deriv := fixed.Derivative()
+	for _, con := range cons {
+		got := fixed.At(con.X)
+		if con.Derivative == 1 {
+			got = deriv.At(con.X)
+		}
+		if math.Abs(got-con.Value) > 1e-6 {
+			t.Fatalf(`Fit() returned %v with derivative %d equal to %v at %v. Expected %v`, fixed, con.Derivative, got, con.X, con.Value)
+		}
+	}
+	if diag.Covariance == nil {
+		t.Fatalf(`Fit() returned no covariance with equality constraints`)
+	}
+
+	// The unconstrained fit wiggles, the monotone one does not
+	free, _, err := Fit(xs, ys, 9, nil)
+	if err != nil {
+		t.Fatalf(`Fit() returned an error: %v`, err)
+	}
+	if min, _, _ := free.Derivative().GlobalExtremaOn(0, 10); min.Y >= 0 {
+		t.Fatalf(`Fit() returned a monotone fit without constraints, the test data is too smooth`)
+	}
+	monotone, _, err := Fit(xs, ys, 9, &FitOptions{Shape: Increasing})
+	if err != nil {
+		t.Fatalf(`Fit() returned an error: %v`, err)
+	}
+	if min, _, _ := monotone.Derivative().GlobalExtremaOn(0, 10); min.Y < -1e-8 {
+		t.Fatalf(`Fit() returned %v with derivative %v at %v for an increasing fit`, monotone, min.Y, min.X)
+	}
+	roots, err := monotone.Sub(CreatePolynomial(5)).RootsWithin(0, 10)
+	if err != nil || len(roots) != 1 {
+		t.Fatalf(`Fit() returned an increasing fit that takes the value 5 at %v`, roots)
+	}
+
+	// Nonnegative fit of data dipping below zero on [-1, 1]
+	xs = []float64{-1, -0.5, 0, 0.5, 1}
+	ys = []float64{1, -0.1, -0.5, -0.1, 1}
+	nonneg, _, err := Fit(xs, ys, 2, &FitOptions{Shape: NonNegative})
+	if err != nil {
+		t.Fatalf(`Fit() returned an error: %v`, err)
+	}
+	if min, _, _ := nonneg.GlobalExtremaOn(-1, 1); min.Y < -1e-8 {
+		t.Fatalf(`Fit() returned %v with value %v at %v for a nonnegative fit`, nonneg, min.Y, min.X)
+	}
+
+	// Decreasing through two increasing values is impossible
+	_, _, err = Fit(xs, ys, 2, &FitOptions{Shape: Decreasing, Constraints: []FitConstraint{{X: -1, Value: 0}, {X: 1, Value: 1}}})
+	if err == nil {
+		t.Fatalf(`Fit() accepted contradicting constraints`)
+	}
+	_, _, err = Fit(xs, ys, 2, &FitOptions{Constraints: []FitConstraint{{X: 0, Value: 0}, {X: 0, Value: 0}}})
+	if err == nil {
+		t.Fatalf(`Fit() accepted repeated constraints`)
+	}
+	_, _, err = Fit(xs, ys, 2, &FitOptions{Shape: NonNegative, ShapeInterval: Interval{A: 0, B: math.Inf(1)}})
+	if err == nil {
+		t.Fatalf(`Fit() accepted an infinite shape interval`)
+	}
+
+	fmt.Println("Constrained Fit ....... OK")
+}
+
+func TestMinimax(t *testing.T) {
+	// The best quadratic approximation to |x| on [-1, 1] is x^2 + 1/8
+	poly, maxErr, err := Minimax(math.Abs, -1, 1, 2)
+	if err != nil {
+		t.Fatalf(`Minimax() returned an error: %v`, err)
+	}
+	want := CreatePolynomial(1, 0, 0.125)
+	for i := range want.coeffs {
+		if math.Abs(poly.coeffs[i]-want.coeffs[i]) > 1e-6 {
+			t.Fatalf(`Minimax() returned %v. Expected %v`, poly, want)
+		}
+	}
+	if math.Abs(maxErr-0.125) > 1e-6 {
+		t.Fatalf(`Minimax() returned error %v. Expected 0.125`, maxErr)
+	}
+
+	// exp on [0, 1], where the error equioscillates at 6 points including
+	// the ends
+	poly, maxErr, err = Minimax(math.Exp, 0, 1, 4)
+	if err != nil {
+		t.Fatalf(`Minimax() returned an error: %v`, err)
+	}
+	worst := 0.0
+	for i := 0; i <= 1000; i++ {
+		x := float64(i) / 1000
+		worst = math.Max(worst, math.Abs(math.Exp(x)-poly.At(x)))
+	}
+	if worst > maxErr*(1+1e-4) || maxErr > 1e-4 {
+		t.Fatalf(`Minimax() returned error %v and the error on a grid is %v`, maxErr, worst)
+	}
+	for _, x := range []float64{0, 1} {
+		if e := math.Abs(math.Exp(x) - poly.At(x)); math.Abs(e-maxErr) > 1e-3*maxErr {
+			t.Fatalf(`Minimax() returned an error of %v at the end %v. Expected %v`, e, x, maxErr)
+		}
+	}
+
+	// Polynomials of the degree are reproduced
+	cubic := CreatePolynomial(2, -1, 0, 3)
+	poly, maxErr, err = Minimax(func(x float64) float64 { return cubic.At(x) }, -2, 5, 3)
+	if err != nil || maxErr > 1e-9 {
+		t.Fatalf(`Minimax() returned %v with error %v, %v for a cubic`, poly, maxErr, err)
+	}
+
+	// High degrees, where the error approaches the rounding error of f.
+	// Horner's method without rounding checks the error on a grid.
+	for _, test := range []struct {
+		f      func(float64) float64
+		a, b   float64
+		degree int
+		bound  float64
+	}{
+		{math.Exp, -1, 1, 10, 2.6e-11},
+		{math.Exp, -1, 1, 15, 1e-14},
+		{math.Exp, -1, 1, 20, 1e-14},
+		{math.Sin, 0, 1, 8, 1.9e-11},
+		{math.Log1p, 0, 1, 12, 1.8e-11},
+	} {
+		poly, maxErr, err := Minimax(test.f, test.a, test.b, test.degree)
+		if err != nil {
+			t.Fatalf(`Minimax() returned an error for degree %d: %v`, test.degree, err)
+		}
+		worst := 0.0
+		for i := 0; i <= 1000; i++ {
+			x := test.a + (test.b-test.a)*float64(i)/1000
+			p := 0.0
+			for _, c := range poly.coeffs {
+				p = p*x + c
+			}
+			worst = math.Max(worst, math.Abs(test.f(x)-p))
+		}
+		if maxErr > test.bound || worst > maxErr*(1+1e-3)+1e-15 {
+			t.Fatalf(`Minimax() returned error %v for degree %d and the error on a grid is %v`, maxErr, test.degree, worst)
+		}
+	}
+
+	if _, _, _, err := remezSolve(math.Exp, []float64{-1, 0, 0, 1}); err == nil {
+		t.Fatalf(`remezSolve() accepted repeated reference points`)
+	}
+	if _, _, err := Minimax(math.Exp, 1, 0, 2); err == nil {
+		t.Fatalf(`Minimax() accepted an empty interval`)
+	}
+
+	fmt.Println("Minimax ............... OK")
+}
+
+func TestChebyshev(t *testing.T) {
+	// T_5 = 16x^5 - 20x^3 + 5x
+	t5 := CreateChebyshevSeries(0, 0, 0, 0, 0, 1)
+	want := CreatePolynomial(16, 0, -20, 0, 5, 0)
+	poly := t5.ToPolynomial()
+	if len(poly.coeffs) != len(want.coeffs) {
+		t.Fatalf(`ToPolynomial() returned %v. Expected %v`, poly, want)
+	}
+	for i := range want.coeffs {
+		if poly.coeffs[i] != want.coeffs[i] {
+			t.Fatalf(`ToPolynomial() returned %v. Expected %v`, poly, want)
+		}
+	}
+	back := want.ToChebyshev().Coeffs()
+	for k, c := range back {
+		if (k == 5 && c != 1) || (k != 5 && c != 0) {
+			t.Fatalf(`ToChebyshev() returned %v. Expected T_5`, back)
+		}
+	}
+
+	// Clenshaw's algorithm agrees with Horner's method
+	mixed := CreateChebyshevSeries(0.5, -1, 2, 0.25, -3)
+	mono := mixed.ToPolynomial()
+	for _, x := range []float64{-1, -0.3, 0, 0.8, 1} {
+		if math.Abs(mixed.At(x)-mono.At(x)) > 1e-10 {
+			t.Fatalf(`At() returned %v at %v. Expected %v`, mixed.At(x), x, mono.At(x))
+		}
+	}
+	if got := mono.ToChebyshev().Coeffs(); math.Abs(got[4]+3) > 1e-12 || math.Abs(got[0]-0.5) > 1e-12 {
+		t.Fatalf(`ToChebyshev() returned %v. Expected %v`, got, mixed.Coeffs())
+	}
+
+	// Interpolation of exp converges geometrically and truncates early
+	exp, err := ChebyshevInterpolate(math.Exp, 30)
+	if err != nil {
+		t.Fatalf(`ChebyshevInterpolate() returned an error: %v`, err)
+	}
+	short := exp.Truncate(1e-13)
+	if short.Degree() >= 20 || short.Degree() < 10 {
+		t.Fatalf(`Truncate() returned degree %d for exp`, short.Degree())
+	}
+	for _, x := range []float64{-1, -0.5, 0.3, 1} {
+		if math.Abs(short.At(x)-math.Exp(x)) > 1e-11 {
+			t.Fatalf(`ChebyshevInterpolate() returned %v for exp at %v. Expected %v`, short.At(x), x, math.Exp(x))
+		}
+	}
+
+	// Degree 200 approximation of Runge's function stays accurate
+	runge, err := ChebyshevInterpolate(func(x float64) float64 { return 1 / (1 + 25*x*x) }, 200)
+	if err != nil {
+		t.Fatalf(`ChebyshevInterpolate() returned an error: %v`, err)
+	}
+	for _, x := range []float64{-0.9, -0.2, 0.3, 0.77} {
+		if math.Abs(runge.At(x)-1/(1+25*x*x)) > 1e-11 {
+			t.Fatalf(`ChebyshevInterpolate() returned %v for Runge's function at %v. Expected %v`, runge.At(x), x, 1/(1+25*x*x))
+		}
+	}
+
+	// Small values are not rounded away
+	tiny, err := ChebyshevInterpolate(func(x float64) float64 { return 1e-14 * math.Exp(x) }, 20)
+	if err != nil {
+		t.Fatalf(`ChebyshevInterpolate() returned an error: %v`, err)
+	}
+	if math.Abs(tiny.At(0.3)-1e-14*math.Exp(0.3)) > 1e-26 {
+		t.Fatalf(`At() returned %v. Expected %v`, tiny.At(0.3), 1e-14*math.Exp(0.3))
+	}
+
+	if _, err := ChebyshevInterpolate(math.Exp, -1); err == nil {
+		t.Fatalf(`ChebyshevInterpolate() accepted a negative degree`)
+	}
+
+	// The roots of T_100 are cos((2k - 1) pi / 200)
+	coeffs := make([]float64, 101)
+	coeffs[100] = 1
+	roots, err := CreateChebyshevSeries(coeffs...).RealRoots()
+	if err != nil {
+		t.Fatalf(`RealRoots() returned an error: %v`, err)
+	}
+	if len(roots) != 100 {
+		t.Fatalf(`RealRoots() returned %d roots of T_100. Expected 100`, len(roots))
+	}
+	sort.Float64s(roots)
+	for k, root := range roots {
+		expected := math.Cos(float64(2*(100-k)-1) * math.Pi / 200)
+		if math.Abs(root-expected) > 1e-10 {
+			t.Fatalf(`RealRoots() returned %v for root %d of T_100. Expected %v`, root, k, expected)
+		}
+	}
+
+	// 2 T_0 + T_1 = x + 2
+	linear, err := CreateChebyshevSeries(2, 1).Roots()
+	if err != nil || len(linear) != 1 || linear[0] != -2 {
+		t.Fatalf(`Roots() returned %v, %v. Expected [-2]`, linear, err)
+	}
+	if _, err := CreateChebyshevSeries(3).ColleagueMatrix(); err == nil {
+		t.Fatalf(`ColleagueMatrix() accepted a constant`)
+	}
+
+	fmt.Println("Chebyshev ............. OK")
+}
@@ -14,6 +14,7 @@ type Polynomial struct {
 	coeffs     []float64
 	sturmChain []*Polynomial
 	SolveMode  SolvingMethod
+	IsolationMode IsolationMethod
 }
 
 // CreatePolynomial returns a new Polynomial
@@ -37,6 +38,7 @@ func CreatePolynomial(coefficients ...float64) *Polynomial {
 	//newPolynomial.RoundCoeffs()
 
 	newPolynomial.SolveMode = DefaultSolvingMethod
+	newPolynomial.IsolationMode = DefaultIsolationMethod
 	return &newPolynomial
 }
 
@@ -119,6 +121,9 @@ func (poly *Polynomial) AtComplex(z complex128) complex128 {
 }
 
 func (poly *Polynomial) IsZero() bool {
+	if len(poly.coeffs) == 0 {
+		return true
+	}
 	return poly.Degree() == 0 && poly.coeffs[0] == 0.0
 }
 
@@ -173,26 +178,13 @@ func (poly1 *Polynomial) EuclideanDiv(poly2 *Polynomial) (*Polynomial, *Polynomi
 		panic("EuclideanDiv division by zero")
 	}
 
-	quotDegree := poly1.Degree() - poly2.Degree()
-	quotCoeffs := make([]float64, quotDegree+1)
-	var d *Polynomial
-	var shift int
-	var factor float64
-
-	r := poly1
-
-	for r.Degree() >= poly2.Degree() {
-		shift = r.Degree() - poly2.Degree()
-		d = poly2.ShiftRight(shift)
-
-		factor = r.LeadingCoeff() / d.LeadingCoeff()
-		quotCoeffs[quotDegree-shift] = factor
-		d = d.ScalarMult(factor)
-		r = r.Sub(d)
+	if len(poly1.coeffs) < len(poly2.coeffs) {
+		return CreatePolynomial(), poly1
 	}
 
-	quotient := CreatePolynomial(quotCoeffs...)
-	return quotient, r
+	quotCoeffs, remCoeffs := divide(poly1.coeffs, poly2.coeffs)
+
+	return CreatePolynomial(quotCoeffs...), CreatePolynomial(remCoeffs...)
 }
 
 func (poly *Polynomial) ShiftRight(offset int) *Polynomial {
@@ -211,18 +203,14 @@ func (poly1 *Polynomial) Sub(poly2 *Polynomial) *Polynomial {
 	coeffs1 := poly1.coeffs
 	coeffs2 := poly2.coeffs
 
-	// Pad "shorter" polynomial with 0s.
+	// Pad "shorter" polynomial with leading 0s.
 	if len(coeffs1) > len(coeffs2) {
 		maxNumCoeffs = len(coeffs1)
-		for len(coeffs2) < maxNumCoeffs {
-			coeffs2 = append(coeffs2, 0.0)
-		}
+		coeffs2 = append(make([]float64, maxNumCoeffs-len(coeffs2)), coeffs2...)
 
 	} else if len(coeffs1) < len(coeffs2) {
 		maxNumCoeffs = len(coeffs2)
-		for len(coeffs1) < maxNumCoeffs {
-			coeffs1 = append(coeffs1, 0.0)
-		}
+		coeffs1 = append(make([]float64, maxNumCoeffs-len(coeffs1)), coeffs1...)
 	} else {
 		maxNumCoeffs = len(coeffs1)
 	}
@@ -238,16 +226,11 @@ func (poly1 *Polynomial) Sub(poly2 *Polynomial) *Polynomial {
 	return newPoly
 }
 
+// Mult returns the product of two polynomials. Products of polynomials with
+// at least FFTMultThreshold coefficients are computed with the FFT when it
+// is accurate enough, see fft.go.
 func (poly1 *Polynomial) Mult(poly2 *Polynomial) *Polynomial {
-	prodCoeffs := make([]float64, poly1.Degree()+poly2.Degree()+1)
-
-	for i := 0; i < len(poly1.coeffs); i++ {
-		for j := 0; j < len(poly2.coeffs); j++ {
-			prodCoeffs[i+j] += poly1.coeffs[i] * poly2.coeffs[j]
-		}
-	}
-
-	prod := CreatePolynomial(prodCoeffs...)
+	prod := CreatePolynomial(convolve(poly1.coeffs, poly2.coeffs)...)
 	return prod
 }
 
@@ -0,0 +1,56 @@
+package polynomials
+
+// Exponentiation by squaring
+// https://en.wikipedia.org/wiki/Exponentiation_by_squaring
+// https://en.wikipedia.org/wiki/Modular_exponentiation
+
+// Pow returns the polynomial raised to the nth power. The zeroth power is
+// the constant polynomial 1.
+func (poly *Polynomial) Pow(n int) *Polynomial {
+	if n < 0 {
+		panic("cannot raise a polynomial to a negative power")
+	}
+
+	result := CreatePolynomial(1)
+	base := poly
+	for n > 0 {
+		if n%2 == 1 {
+			result = result.Mult(base)
+		}
+		n /= 2
+		if n > 0 {
+			base = base.Mult(base)
+		}
+	}
+
+	return result
+}
+
+// PowMod returns the remainder of the polynomial raised to the nth power
+// divided by m. The remainder is taken after every multiplication, so the
+// intermediate results stay below the degree of m.
+func (poly *Polynomial) PowMod(n int, m *Polynomial) *Polynomial {
+	if n < 0 {
+		panic("cannot raise a polynomial to a negative power")
+	}
+	if m == nil {
+		panic("received nil *Polynomial")
+	}
+	if m.IsZero() {
+		panic("PowMod division by zero")
+	}
+
+	_, result := CreatePolynomial(1).EuclideanDiv(m)
+	_, base := poly.EuclideanDiv(m)
+	for n > 0 {
+		if n%2 == 1 {
+			_, result = result.Mult(base).EuclideanDiv(m)
+		}
+		n /= 2
+		if n > 0 {
+			_, base = base.Mult(base).EuclideanDiv(m)
+		}
+	}
+
+	return result
+}
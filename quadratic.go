@@ -4,44 +4,117 @@ import (
 	"math"
 )
 
-
-
+// Numerically stable quadratic formula
+// https://en.wikipedia.org/wiki/Quadratic_formula#Numerical_calculation
+// https://people.eecs.berkeley.edu/~wkahan/Qdrtcs.pdf
+//
+// The variable is substituted by x = 2^k*y so that the coefficients of y^2
+// and 1 have comparable magnitudes, and the coefficients are then scaled by
+// a power of two so that the discriminant cannot overflow. Both scalings are
+// exact, and the roots in y are multiplied back by 2^k. The discriminant is computed with fused multiply-adds
+// to recover the digits lost to cancellation when b^2 is close to 4ac.
+// The root of larger modulus is computed as q/a with
+// q = -(b + sign(b)*sqrt(D))/2 and the smaller one as c/q, which avoids
+// the cancellation of the textbook formula when b^2 >> 4ac.
+//
+// A discriminant smaller than EpsQuadratic relative to b^2 and 4ac is treated
+// as zero and a single double root is returned.
+// Polynomials of degree one and zero are also accepted.
 
 func (poly *Polynomial) QuadraticRoots() ([]complex128){
-	if poly.Degree() != 2 { panic("cannot use quatratic formula on non-quatratic polynomial") }
+	switch poly.Degree() {
+	case 0:
+		return []complex128{}
+	case 1:
+		return []complex128{complex(-poly.coeffs[1]/poly.coeffs[0], 0)}
+	case 2:
+	default:
+		panic("cannot use quatratic formula on non-quatratic polynomial")
+	}
 
-	a := poly.coeffs[0]
-	b := poly.coeffs[1]
-	c := poly.coeffs[2]
+	a, b, c, k := balancedQuadratic(poly.coeffs[0], poly.coeffs[1], poly.coeffs[2])
 
-	discriminant := b*b - 4.0*a*c
-	if discriminant == 0 {
+	discriminant := quadraticDiscriminant(a, b, c)
 
-		realPart := (-b) / (2.0*a)
+	if math.Abs(discriminant) <= EpsQuadratic*(b*b+math.Abs(4.0*a*c)) {
+
+		realPart := math.Ldexp((-b) / (2.0*a), k)
 		root := complex(realPart, 0)
 
-		return[]complex128{root}
+		return []complex128{root}
 
 	} else if discriminant > 0 {
 
-		real1 := (-b + math.Sqrt(discriminant) ) / (2.0*a)
-	    real2 := (-b - math.Sqrt(discriminant) ) / (2.0*a)
+		q := -0.5 * (b + math.Copysign(math.Sqrt(discriminant), b))
 
-	    root1 := complex(real1, 0)
-	    root2 := complex(real2, 0)
+		root1 := complex(math.Ldexp(q/a, k), 0)
+		root2 := complex(math.Ldexp(c/q, k), 0)
 
-	    return []complex128{root1, root2}
+		return []complex128{root1, root2}
 
 	} else { // complex roots
-		realPart := -b / (2.0*a)
-		imgPart  := math.Sqrt(-discriminant) / (2.0*a)
+		realPart := math.Ldexp(-b / (2.0*a), k)
+		imgPart  := math.Ldexp(math.Sqrt(-discriminant) / (2.0*math.Abs(a)), k)
 		root1 := complex(realPart, +imgPart)
 		root2 := complex(realPart, -imgPart)
 
-
 		return []complex128{root1, root2}
 	}
+}
 
+// Scales the coefficients exactly by 2^-exp so that the largest of them
+// lies in [0.5, 1). The roots are unchanged. Returns the scaled
+// coefficients and exp.
+func scaledQuadratic(a float64, b float64, c float64) (float64, float64, float64, int) {
+	m := math.Max(math.Abs(a), math.Max(math.Abs(b), math.Abs(c)))
+	if m == 0 || math.IsInf(m, 0) {
+		return a, b, c, 0
+	}
 
+	_, exp := math.Frexp(m)
+	return math.Ldexp(a, -exp), math.Ldexp(b, -exp), math.Ldexp(c, -exp), exp
 }
 
+// Substitutes x = 2^k*y into ax^2 + bx + c, with k chosen so that the
+// coefficients of y^2 and 1 have comparable exponents, and scales the result
+// like scaledQuadratic. Each coefficient is multiplied by a single power of
+// two, so nothing overflows or underflows on the way unless the roots
+// themselves are out of range. Returns the coefficients in y and k.
+func balancedQuadratic(a float64, b float64, c float64) (float64, float64, float64, int) {
+	if a == 0 || math.IsInf(a, 0) || math.IsNaN(a) {
+		a, b, c, _ = scaledQuadratic(a, b, c)
+		return a, b, c, 0
+	}
+
+	_, ea := math.Frexp(a)
+	_, eb := math.Frexp(b)
+	_, ec := math.Frexp(c)
+
+	k := 0
+	if c != 0 {
+		k = (ec - ea) / 2
+	}
+
+	// Largest exponent after the substitution
+	m := ea + 2*k
+	if c != 0 && ec > m {
+		m = ec
+	}
+	if b != 0 && eb+k > m {
+		m = eb + k
+	}
+
+	return math.Ldexp(a, 2*k-m), math.Ldexp(b, k-m), math.Ldexp(c, -m), k
+}
+
+// Computes b^2 - 4ac, correcting the rounding errors of both products with
+// fused multiply-adds
+func quadraticDiscriminant(a float64, b float64, c float64) float64 {
+	bb := b * b
+	ac := 4.0 * a * c
+
+	bbErr := math.FMA(b, b, -bb)
+	acErr := math.FMA(4.0*a, c, -ac)
+
+	return (bb - ac) + (bbErr - acErr)
+}
@@ -0,0 +1,113 @@
+package polynomials
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// Closed form solution of the quartic equation with Ferrari's method
+// https://en.wikipedia.org/wiki/Quartic_function#Ferrari's_solution
+//
+// The monic quartic is depressed to y^4 + py^2 + qy + r with x = y - a/4 and
+// factored into two quadratics using the largest real root m of the
+// resolvent cubic m^3 + pm^2 + (p^2/4 - r)m - q^2/8.
+//
+// As with the cubic, only the root of largest modulus is taken from the
+// formula. It is divided out starting from the constant term, either as a
+// linear factor or together with its conjugate as a quadratic factor, and
+// the remaining cubic or quadratic is solved separately. All roots are
+// finally polished with Newton's method against the original polynomial.
+
+func (poly *Polynomial) QuarticRoots() []complex128 {
+	if poly.Degree() != 4 { panic("cannot use quartic formula on non-quartic polynomial") }
+
+	lead := poly.coeffs[0]
+	a := poly.coeffs[1] / lead
+	b := poly.coeffs[2] / lead
+	c := poly.coeffs[3] / lead
+	d := poly.coeffs[4] / lead
+
+	largest := poly.polishRoots([]complex128{largestRoot(ferrariFormula(a, b, c, d))})[0]
+
+	if largest == 0 {
+		return []complex128{0, 0, 0, 0}
+	}
+
+	var roots []complex128
+
+	if imag(largest) == 0 {
+		// x^4 + ax^3 + bx^2 + cx + d = (x - x1)(x^3 + a'x^2 + b'x + c')
+		x1 := real(largest)
+		c1 := -d / x1
+		b1 := (c1 - c) / x1
+		a1 := (b1 - b) / x1
+		roots = append([]complex128{largest}, CreatePolynomial(1, a1, b1, c1).CubicRoots()...)
+	} else {
+		// x^4 + ax^3 + bx^2 + cx + d = (x^2 + ux + v)(x^2 + u'x + v')
+		u := -2.0 * real(largest)
+		v := real(largest)*real(largest) + imag(largest)*imag(largest)
+		v1 := d / v
+		u1 := (c - u*v1) / v
+		roots = append([]complex128{largest, cmplx.Conj(largest)}, monicQuadraticRoots(u1, v1)...)
+	}
+
+	return poly.polishRoots(roots)
+}
+
+// Roots of x^4 + ax^3 + bx^2 + cx + d from Ferrari's solution
+func ferrariFormula(a float64, b float64, c float64, d float64) []complex128 {
+	shift := a / 4.0
+	p := b - 6.0*shift*shift
+	q := c - 2.0*b*shift + 8.0*shift*shift*shift
+	r := d - c*shift + b*shift*shift - 3.0*shift*shift*shift*shift
+
+	var ys []complex128
+
+	resolvent := CreatePolynomial(1, p, p*p/4.0-r, -q*q/8.0)
+	m := math.Inf(-1)
+	for _, root := range resolvent.CubicRoots() {
+		if imag(root) == 0 && real(root) > m {
+			m = real(root)
+		}
+	}
+
+	if q == 0 || m <= 0 {
+		// Biquadratic, y^4 + py^2 + r
+		disc := cmplx.Sqrt(complex(p*p-4.0*r, 0))
+		for _, y2 := range []complex128{(complex(-p, 0) + disc) / 2.0, (complex(-p, 0) - disc) / 2.0} {
+			y := cmplx.Sqrt(y2)
+			ys = append(ys, y, -y)
+		}
+	} else {
+		// (y^2 + p/2 + m)^2 = (sqrt(2m) y - q / (2 sqrt(2m)))^2
+		s := math.Sqrt(2.0 * m)
+		t := q / (2.0 * s)
+		ys = append(ys, monicQuadraticRoots(-s, p/2.0+m+t)...)
+		ys = append(ys, monicQuadraticRoots(s, p/2.0+m-t)...)
+	}
+
+	roots := make([]complex128, len(ys))
+	for idx, y := range ys {
+		roots[idx] = y - complex(shift, 0)
+	}
+
+	return roots
+}
+
+// Roots of x^2 + bx + c, real roots are returned with zero imaginary part
+func monicQuadraticRoots(b float64, c float64) []complex128 {
+	disc := b*b - 4.0*c
+
+	if disc >= 0 {
+		// Avoid cancellation by computing the larger root first
+		q := -0.5 * (b + math.Copysign(math.Sqrt(disc), b))
+		if q == 0 {
+			return []complex128{0, 0}
+		}
+		return []complex128{complex(q, 0), complex(c/q, 0)}
+	}
+
+	realPart := -b / 2.0
+	imgPart := math.Sqrt(-disc) / 2.0
+	return []complex128{complex(realPart, imgPart), complex(realPart, -imgPart)}
+}
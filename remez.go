@@ -0,0 +1,278 @@
+package polynomials
+
+import (
+	"errors"
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Minimax approximation with the Remez exchange algorithm
+// https://en.wikipedia.org/wiki/Remez_algorithm
+// https://en.wikipedia.org/wiki/Equioscillation_theorem
+//
+// The best uniform approximation p of degree n to a continuous f on [a, b]
+// is characterized by an error e = f - p that takes its maximum absolute
+// value E at n + 2 points with alternating signs. Starting from the
+// Chebyshev extrema, every iteration solves for the polynomial with the
+// levelled error e(x_i) = (-1)^i E at the reference points x_i. The new
+// reference is found from samples of e, split into runs of the same sign.
+// The largest |e| of every run is refined by golden-section search within
+// the run, and runs are dropped until n + 2 alternating extrema remain,
+// always the smaller end or the adjacent pair of smallest |e|. The error of
+// the best approximation lies between |E| and max |e|, and the iteration
+// stops when they differ by less than EpsRemez relative to max |e|, or by
+// less than a few ulps of max |f|, below which the values of f carry no
+// more information. It also stops with the best approximation so far when
+// max |e| no longer decreases, which happens once rounding errors dominate.
+//
+// As in Fit, the polynomial is computed in t = (x - c) / h on [-1, 1]. The
+// levelled system is solved in the Chebyshev basis, since the Vandermonde
+// matrix of the monomials is numerically singular from degree 15.
+
+// Minimax returns the polynomial of at most the given degree with the
+// smallest maximum error max |f(x) - p(x)| on [a, b], and that error
+func Minimax(f func(float64) float64, a float64, b float64, degree int) (*Polynomial, float64, error) {
+	if degree < 0 {
+		return nil, 0, errors.New("degree must not be negative")
+	}
+	if !(a < b) {
+		return nil, 0, errors.New("invalid interval")
+	}
+
+	errNotConverged := errors.New("Remez algorithm didn't converge before max number of iterations was reached! Result may be incorrect")
+
+	c, h := (a+b)/2, (b-a)/2
+	g := func(t float64) float64 {
+		return f(c + h*t)
+	}
+
+	m := degree + 2
+	ref := make([]float64, m)
+	for i := range ref {
+		ref[i] = -math.Cos(math.Pi * float64(i) / float64(m-1))
+	}
+
+	var best *ChebyshevSeries
+	bestErr := math.Inf(1)
+	for iter := 0; iter < MaxRemezIterations; iter++ {
+		series, levelled, maxF, err := remezSolve(g, ref)
+		if err != nil {
+			if best == nil {
+				return nil, 0, err
+			}
+			return remezPolynomial(best, c, h), bestErr, err
+		}
+
+		e := func(t float64) float64 {
+			return g(t) - series.At(t)
+		}
+
+		next, maxErr := remezExchange(e, m)
+		if math.IsNaN(maxErr) {
+			return nil, 0, errors.New("f returned NaN")
+		}
+
+		floor := 4 * machEps * maxF
+		if maxErr-math.Abs(levelled) <= math.Max(EpsRemez*maxErr, floor) {
+			return remezPolynomial(series, c, h), maxErr, nil
+		}
+
+		// The error no longer decreases before the gap has closed
+		if maxErr >= bestErr {
+			return remezPolynomial(best, c, h), bestErr, errNotConverged
+		}
+		best, bestErr = series, maxErr
+
+		if len(next) < m {
+			return remezPolynomial(best, c, h), bestErr, errors.New("error of the Remez algorithm doesn't alternate at enough points")
+		}
+		ref = next
+	}
+
+	return remezPolynomial(best, c, h), bestErr, errNotConverged
+}
+
+// Solves p(t_i) + (-1)^i E = g(t_i) for p as a Chebyshev series and the
+// levelled error E. Also returns max |g(t_i)|.
+func remezSolve(g func(float64) float64, ref []float64) (*ChebyshevSeries, float64, float64, error) {
+	m := len(ref)
+	for i := 1; i < m; i++ {
+		if !(ref[i-1] < ref[i]) {
+			return nil, 0, 0, errors.New("reference points of the Remez algorithm coincide")
+		}
+	}
+
+	system := mat.NewDense(m, m, nil)
+	rhs := mat.NewVecDense(m, nil)
+	maxG := 0.0
+	sign := 1.0
+	for i, t := range ref {
+		// T_0, T_1, ... with T_(k+1) = 2t T_k - T_(k-1), where T_(-1) = t
+		prev, cur := t, 1.0
+		for j := 0; j < m-1; j++ {
+			system.Set(i, j, cur)
+			prev, cur = cur, 2*t*cur-prev
+		}
+		system.Set(i, m-1, sign)
+		sign = -sign
+
+		y := g(t)
+		rhs.SetVec(i, y)
+		maxG = math.Max(maxG, math.Abs(y))
+	}
+
+	var sol mat.VecDense
+	if err := sol.SolveVec(system, rhs); err != nil {
+		return nil, 0, 0, err
+	}
+
+	coeffs := make([]float64, m-1)
+	for j := range coeffs {
+		coeffs[j] = sol.AtVec(j)
+	}
+	return CreateChebyshevSeries(coeffs...), sol.AtVec(m - 1), maxG, nil
+}
+
+// The series in t as a polynomial in x = c + h t
+func remezPolynomial(series *ChebyshevSeries, c float64, h float64) *Polynomial {
+	q := series.ToPolynomial()
+	if len(q.coeffs) == 0 {
+		return q
+	}
+	return CreatePolynomial(unscaleFit(q.coeffs, c, h)...)
+}
+
+// Extrema of e with alternating signs for the next reference, at most m of
+// them, and max |e| on [-1, 1]
+func remezExchange(e func(float64) float64, m int) ([]float64, float64) {
+	type run struct {
+		lo, hi, best int // sample indices
+		sign         float64
+	}
+
+	n := 20 * m
+	ts := make([]float64, n)
+	vals := make([]float64, n)
+	runs := []run{}
+	for j := range ts {
+		ts[j] = -math.Cos(math.Pi * float64(j) / float64(n-1))
+		vals[j] = e(ts[j])
+		if math.IsNaN(vals[j]) {
+			return nil, math.NaN()
+		}
+		if vals[j] == 0 {
+			continue
+		}
+
+		sign := 1.0
+		if vals[j] < 0 {
+			sign = -1.0
+		}
+		last := len(runs) - 1
+		if last >= 0 && runs[last].sign == sign {
+			runs[last].hi = j
+			if math.Abs(vals[j]) > math.Abs(vals[runs[last].best]) {
+				runs[last].best = j
+			}
+			continue
+		}
+		runs = append(runs, run{lo: j, hi: j, best: j, sign: sign})
+	}
+
+	// Refine the extremum of every run between the neighbouring samples
+	ext := make([]float64, len(runs))
+	errs := make([]float64, len(runs))
+	maxErr := 0.0
+	for i, r := range runs {
+		lo := ts[r.lo]
+		if r.best > r.lo {
+			lo = ts[r.best-1]
+		}
+		hi := ts[r.hi]
+		if r.best < r.hi {
+			hi = ts[r.best+1]
+		}
+		sign := r.sign
+		ext[i] = maximizeFunc(func(t float64) float64 { return sign * e(t) }, lo, hi)
+		errs[i] = math.Abs(e(ext[i]))
+		maxErr = math.Max(maxErr, errs[i])
+	}
+
+	// A symmetric reference can level the error at E = 0, which leaves
+	// only m - 1 runs between the reference points. The ends of the
+	// interval restart the iteration from an asymmetric reference.
+	if len(ext) < m && len(ext) > 0 && ext[0] > -1 {
+		ext = append([]float64{-1}, ext...)
+		errs = append([]float64{math.Abs(e(-1))}, errs...)
+	}
+	if len(ext) < m && len(ext) > 0 && ext[len(ext)-1] < 1 {
+		ext = append(ext, 1)
+		errs = append(errs, math.Abs(e(1)))
+	}
+
+	for len(ext) > m {
+		if len(ext) == m+1 {
+			// Drop the smaller end
+			if errs[0] < errs[len(errs)-1] {
+				ext, errs = ext[1:], errs[1:]
+			} else {
+				ext, errs = ext[:len(ext)-1], errs[:len(errs)-1]
+			}
+			continue
+		}
+
+		// Drop the adjacent pair of smallest errors, which keeps the signs
+		// alternating
+		k := 0
+		for i := 1; i+1 < len(errs); i++ {
+			if errs[i]+errs[i+1] < errs[k]+errs[k+1] {
+				k = i
+			}
+		}
+		ext = append(ext[:k:k], ext[k+2:]...)
+		errs = append(errs[:k:k], errs[k+2:]...)
+	}
+
+	return ext, maxErr
+}
+
+// Point of the largest value of f on [a, b], from a coarse sampling refined
+// by golden-section search around the best sample
+func maximizeFunc(f func(float64) float64, a float64, b float64) float64 {
+	const samples = 16
+	step := (b - a) / samples
+	best, bestVal := a, f(a)
+	for i := 1; i <= samples; i++ {
+		x := a + float64(i)*step
+		if i == samples {
+			x = b
+		}
+		if v := f(x); v > bestVal {
+			best, bestVal = x, v
+		}
+	}
+
+	lo, hi := math.Max(a, best-step), math.Min(b, best+step)
+	invPhi := (math.Sqrt(5) - 1) / 2
+	x1 := hi - invPhi*(hi-lo)
+	x2 := lo + invPhi*(hi-lo)
+	f1, f2 := f(x1), f(x2)
+	for i := 0; i < 100 && hi-lo > 4*machEps*math.Max(1, math.Abs(best)); i++ {
+		if f1 < f2 {
+			lo, x1, f1 = x1, x2, f2
+			x2 = lo + invPhi*(hi-lo)
+			f2 = f(x2)
+		} else {
+			hi, x2, f2 = x2, x1, f1
+			x1 = hi - invPhi*(hi-lo)
+			f1 = f(x1)
+		}
+	}
+
+	mid := (lo + hi) / 2
+	if f(mid) > bestVal {
+		return mid
+	}
+	return best
+}
@@ -7,7 +7,7 @@ import (
 
 
 
-// Package has three solving methods
+// Package has six solving methods
 // =================================
 // 1. Durand-Kerner method 
 //    https://en.wikipedia.org/wiki/Durand–Kerner_method
@@ -20,6 +20,15 @@ import (
 // 	  https://en.wikipedia.org/wiki/Eigenvalue_algorithm#Algorithms
 // 	  https://en.wikipedia.org/wiki/Companion_matrix
 //
+// 4. Aberth-Ehrlich method
+//    https://en.wikipedia.org/wiki/Aberth_method
+//
+// 5. Jenkins-Traub method for real coefficients (RPOLY)
+//    https://en.wikipedia.org/wiki/Jenkins–Traub_algorithm
+//
+// 6. Laguerre's method with deflation and polishing
+//    https://en.wikipedia.org/wiki/Laguerre%27s_method
+//
 // The third method is usually the most robust
 
 
@@ -29,6 +38,9 @@ const (
     DurandKerner SolvingMethod = iota
     BisectionNewton
     Eigenvalue 
+    Aberth
+    JenkinsTraub
+    Laguerre
 )
 
 
@@ -75,6 +87,13 @@ func (poly *Polynomial) RealRoots() ([]float64, error){
 	} else if poly.Degree() == 2 {
 		complexRoots := poly.QuadraticRoots()
 		realRoots = getRealParts(complexRoots)
+	} else if poly.Degree() == 3 || poly.Degree() == 4 {
+		// Closed form solutions, SolveMode only applies from degree 5
+		complexRoots, err := poly.ComplexRoots()
+		if err != nil {
+			return realRoots, err
+		}
+		realRoots = getRealParts(complexRoots)
 	} else {
 		switch poly.SolveMode {
 		case DurandKerner:
@@ -98,6 +117,27 @@ func (poly *Polynomial) RealRoots() ([]float64, error){
 				return realRoots, err
 			}
 			realRoots = getRealParts(complexRoots)
+
+		case Aberth:
+			complexRoots, err := poly.ComplexRootsAberth()
+			if err != nil {
+				return realRoots, err
+			}
+			realRoots = getRealParts(complexRoots)
+
+		case JenkinsTraub:
+			complexRoots, err := poly.ComplexRootsJenkinsTraub()
+			if err != nil {
+				return realRoots, err
+			}
+			realRoots = getRealParts(complexRoots)
+
+		case Laguerre:
+			complexRoots, err := poly.ComplexRootsLaguerre()
+			if err != nil {
+				return realRoots, err
+			}
+			realRoots = getRealParts(complexRoots)
 		}
 
 	}
@@ -116,6 +156,22 @@ func (poly *Polynomial) ComplexRoots() ([]complex128, error){
 
 		return poly.QuadraticRoots(), nil
 
+	} else if poly.Degree() == 3 || poly.Degree() == 4 {
+
+		// Closed form solutions, SolveMode only applies from degree 5
+		var roots []complex128
+		if poly.Degree() == 3 {
+			roots = poly.CubicRoots()
+		} else {
+			roots = poly.QuarticRoots()
+		}
+
+		for idx, root := range roots {
+			roots[idx] = RoundC(root)
+		}
+
+		return roots, nil
+
 	} else {
 		switch poly.SolveMode {
 		case DurandKerner:
@@ -126,6 +182,15 @@ func (poly *Polynomial) ComplexRoots() ([]complex128, error){
 
 		case Eigenvalue:
 			return poly.ComplexRootsEigenvalue()
+
+		case Aberth:
+			return poly.ComplexRootsAberth()
+
+		case JenkinsTraub:
+			return poly.ComplexRootsJenkinsTraub()
+
+		case Laguerre:
+			return poly.ComplexRootsLaguerre()
 		}
 
 		return []complex128{}, errors.New("Invalid solve mode")
@@ -147,6 +212,45 @@ func (poly *Polynomial) ComplexRootsDurandKerner() ([]complex128, error){
 	return roots, nil
 }
 
+func (poly *Polynomial) ComplexRootsAberth() ([]complex128, error){
+	roots, err := poly.AberthRoots()
+	if err != nil {
+		return []complex128{}, err
+	}
+
+	for idx, root := range roots {
+		roots[idx] = RoundC(root)
+	}
+
+	return roots, nil
+}
+
+func (poly *Polynomial) ComplexRootsJenkinsTraub() ([]complex128, error){
+	roots, err := poly.JenkinsTraubRoots()
+	if err != nil {
+		return []complex128{}, err
+	}
+
+	for idx, root := range roots {
+		roots[idx] = RoundC(root)
+	}
+
+	return roots, nil
+}
+
+func (poly *Polynomial) ComplexRootsLaguerre() ([]complex128, error){
+	roots, err := poly.LaguerreRoots()
+	if err != nil {
+		return []complex128{}, err
+	}
+
+	for idx, root := range roots {
+		roots[idx] = RoundC(root)
+	}
+
+	return roots, nil
+}
+
 func (poly *Polynomial) ComplexRootsEigenvalue() ([]complex128, error){
 	poly.MakeMonic()
 	companionMatrix, err := poly.CompanionMatrix()
@@ -171,8 +275,12 @@ func (poly *Polynomial) ComplexRootsEigenvalue() ([]complex128, error){
 }
 
 func (poly *Polynomial) RootsBisectionNewton() ([]float64, error){
-	lowerBound, upperBound := poly.RootBounds()
-	roots, err := poly.RootsWithin(lowerBound, upperBound)
+	// Sturm's theorem and Newton's method both require simple roots
+	squarefree := poly.SquarefreePart()
+	squarefree.IsolationMode = poly.IsolationMode
+
+	lowerBound, upperBound := squarefree.RootBounds()
+	roots, err := squarefree.RootsWithin(lowerBound, upperBound)
 
 	if err != nil {
 		return []float64{}, err
@@ -193,14 +301,27 @@ func (poly *Polynomial) RootsWithin(lowerBound float64, upperBound float64) ([]f
 	}
 
 	roots := []float64{}
-	// Check if lowerBound is a root
-	if poly.At(lowerBound) == 0.0 {
-		roots = append(roots, lowerBound)
+	var isolationIntervals []Interval
+
+	switch poly.IsolationMode {
+	case DescartesIsolation:
+		intervals, err := poly.DescartesIsolation(lowerBound, upperBound)
+		if err != nil {
+			return roots, err
+		}
+		isolationIntervals = intervals
+
+	default:
+		// Check if lowerBound is a root
+		if poly.At(lowerBound) == 0.0 {
+			roots = append(roots, lowerBound)
+		}
+
+		isolationIntervals = poly.findIsolationIntervals(lowerBound, upperBound)
 	}
 
-	isolationIntervals := poly.findIsolationIntervals(lowerBound, upperBound)
 	for _, isolationInterval := range isolationIntervals {
-		root, err := poly.NewtonMethod(isolationInterval.Mid())
+		root, err := poly.newtonWithin(isolationInterval)
 		if err != nil {
 			return roots, err
 		}
@@ -0,0 +1,141 @@
+package polynomials
+
+import (
+	"math"
+)
+
+// Stability criteria for characteristic polynomials
+// https://en.wikipedia.org/wiki/Routh%E2%80%93Hurwitz_stability_criterion
+// https://en.wikipedia.org/wiki/Jury_stability_criterion
+//
+// Both tests decide stability from the coefficients with a table of
+// determinants, without computing any roots. Table entries smaller than
+// EpsStability relative to the entries they were computed from are treated
+// as zero.
+
+// IsHurwitzStable tests whether all roots of the polynomial lie in the open
+// left half plane, which is the stability condition of continuous-time
+// systems. It returns the Routh array and the number of roots in the open
+// right half plane, which equals the number of sign changes in the first
+// column of the array.
+//
+// A zero in the first column is replaced by a small positive number, and a
+// row of zeros by the derivative of the auxiliary polynomial formed from the
+// row above it. Both cases mean that the polynomial is not stable.
+func (poly *Polynomial) IsHurwitzStable() (bool, [][]float64, int) {
+	if poly.IsZero() {
+		return false, nil, 0
+	}
+
+	n := poly.Degree()
+	rows := make([][]float64, n+1)
+	for i := range rows {
+		rows[i] = make([]float64, (n-i)/2+1)
+	}
+	for j := 0; j <= n; j++ {
+		rows[j%2][j/2] = poly.coeffs[j]
+	}
+
+	at := func(row []float64, j int) float64 {
+		if j < len(row) {
+			return row[j]
+		}
+		return 0
+	}
+
+	degenerate := false
+	for i := 1; i <= n; i++ {
+		prev := rows[i-1]
+		scale := maxAbs(prev)
+
+		if i >= 2 {
+			prev2 := rows[i-2]
+			scale = math.Max(scale, maxAbs(prev2))
+			for j := range rows[i] {
+				rows[i][j] = (prev[0]*at(prev2, j+1) - prev2[0]*at(prev, j+1)) / prev[0]
+			}
+		}
+
+		for j := range rows[i] {
+			if math.Abs(rows[i][j]) <= EpsStability*scale {
+				rows[i][j] = 0
+			}
+		}
+
+		if maxAbs(rows[i]) == 0 {
+			// Derivative of the auxiliary polynomial prev[0] s^m + prev[1] s^(m-2) + ...
+			m := n - (i - 1)
+			for j := range rows[i] {
+				rows[i][j] = at(prev, j) * float64(m-2*j)
+			}
+			degenerate = true
+		}
+
+		if rows[i][0] == 0 {
+			rows[i][0] = EpsStability * scale
+			degenerate = true
+		}
+	}
+
+	rhp := 0
+	for i := 1; i <= n; i++ {
+		if (rows[i][0] < 0) != (rows[i-1][0] < 0) {
+			rhp++
+		}
+	}
+
+	return rhp == 0 && !degenerate, rows, rhp
+}
+
+// IsSchurStable tests whether all roots of the polynomial lie inside the
+// open unit disk, which is the stability condition of discrete-time systems.
+// It returns the rows of the Jury table, each one shorter than the previous,
+// with coefficients in increasing order of degree. The reversed rows of the
+// classic table are omitted.
+//
+// Each row is formed from the previous one a_0, ..., a_m as
+// b_(j-1) = a_m a_j - a_0 a_(m-j), and the polynomial is stable if
+// |a_0| < |a_m| holds for every row.
+func (poly *Polynomial) IsSchurStable() (bool, [][]float64) {
+	if poly.IsZero() {
+		return false, nil
+	}
+
+	row := make([]float64, len(poly.coeffs))
+	copy(row, poly.coeffs)
+	Reverse(row)
+
+	rows := [][]float64{row}
+	stable := true
+
+	for m := len(row) - 1; m >= 1; m-- {
+		if !(math.Abs(row[0]) < (1.0-EpsStability)*math.Abs(row[m])) {
+			stable = false
+		}
+
+		scale := math.Max(math.Abs(row[0]), math.Abs(row[m])) * maxAbs(row)
+		next := make([]float64, m)
+		for j := 1; j <= m; j++ {
+			next[j-1] = row[m]*row[j] - row[0]*row[m-j]
+			if math.Abs(next[j-1]) <= EpsStability*scale {
+				next[j-1] = 0
+			}
+		}
+
+		row = next
+		rows = append(rows, row)
+	}
+
+	return stable, rows
+}
+
+// Returns the largest absolute value in s
+func maxAbs(s []float64) float64 {
+	m := 0.0
+	for _, x := range s {
+		if math.Abs(x) > m {
+			m = math.Abs(x)
+		}
+	}
+	return m
+}
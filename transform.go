@@ -0,0 +1,68 @@
+package polynomials
+
+// Changes of variable
+// https://en.wikipedia.org/wiki/Function_composition
+// https://en.wikipedia.org/wiki/Reciprocal_polynomial
+
+// Compose returns the polynomial p(q(x)), computed with Horner's scheme
+func (poly *Polynomial) Compose(q *Polynomial) *Polynomial {
+	if q == nil {
+		panic("received nil *Polynomial")
+	}
+
+	result := []float64{}
+	for _, c := range poly.coeffs {
+		// result = result * q + c
+		prod := []float64{0}
+		if len(result) > 0 && len(q.coeffs) > 0 {
+			prod = make([]float64, len(result)+len(q.coeffs)-1)
+		}
+		for i := 0; i < len(result); i++ {
+			for j := 0; j < len(q.coeffs); j++ {
+				prod[i+j] += result[i] * q.coeffs[j]
+			}
+		}
+		prod[len(prod)-1] += c
+		result = prod
+	}
+
+	return CreatePolynomial(result...)
+}
+
+// TaylorShift returns the polynomial p(x + c). Runs in O(n^2).
+func (poly *Polynomial) TaylorShift(c float64) *Polynomial {
+	coeffs := make([]float64, len(poly.coeffs))
+	copy(coeffs, poly.coeffs)
+
+	Reverse(coeffs)
+	taylorShiftAscending(coeffs, c)
+	Reverse(coeffs)
+
+	return CreatePolynomial(coeffs...)
+}
+
+// ScaleVariable returns the polynomial p(a*x)
+func (poly *Polynomial) ScaleVariable(a float64) *Polynomial {
+	n := len(poly.coeffs)
+	coeffs := make([]float64, n)
+
+	power := 1.0
+	for i := n - 1; i >= 0; i-- {
+		coeffs[i] = poly.coeffs[i] * power
+		power *= a
+	}
+
+	return CreatePolynomial(coeffs...)
+}
+
+// Reciprocal returns the polynomial x^n p(1/x), where n is the degree of
+// the polynomial. Its coefficients are those of the polynomial in reverse
+// order and its roots are the reciprocals of the nonzero roots. If p(0) = 0
+// the degree of the result is lower than n.
+func (poly *Polynomial) Reciprocal() *Polynomial {
+	coeffs := make([]float64, len(poly.coeffs))
+	copy(coeffs, poly.coeffs)
+	Reverse(coeffs)
+
+	return CreatePolynomial(coeffs...)
+}
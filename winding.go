@@ -0,0 +1,186 @@
+package polynomials
+
+import (
+	"errors"
+	"math"
+	"math/cmplx"
+)
+
+// Counting complex roots with the argument principle
+// https://en.wikipedia.org/wiki/Argument_principle
+//
+// The number of roots inside a closed contour equals the winding number of
+// p(z) around the origin as z travels once around the contour. The phase of
+// p is tracked along the contour starting from a few samples per degree,
+// and a segment is split in two until the phase changes by less than
+// pi/8 on both of its halves.
+//
+// The count is undefined if a root lies on the contour. Values of p that
+// are smaller than EpsContour relative to the magnitude of the terms summed
+// to evaluate them are taken to be roots on the contour, and so are segments
+// that still need splitting after MaxContourDepth bisections.
+
+// Largest phase change accepted on a segment of the contour
+const maxPhaseStep = math.Pi / 8.0
+
+// CountRootsInDisk returns the number of roots inside the open disk
+// |z - center| < radius, counted with multiplicity
+func (poly *Polynomial) CountRootsInDisk(center complex128, radius float64) (int, error) {
+	if poly.IsZero() {
+		return 0, errors.New("infinitely many solutions")
+	}
+	if !(radius > 0) {
+		return 0, errors.New("invalid radius")
+	}
+
+	path := func(t float64) complex128 {
+		return center + complex(radius, 0)*cmplx.Exp(complex(0, 2.0*math.Pi*t))
+	}
+
+	return windingNumber(poly.evalWithBound, path, poly.Degree())
+}
+
+// CountRootsInRectangle returns the number of roots inside the open
+// rectangle with lower left corner lo and upper right corner hi, counted
+// with multiplicity
+func (poly *Polynomial) CountRootsInRectangle(lo complex128, hi complex128) (int, error) {
+	if poly.IsZero() {
+		return 0, errors.New("infinitely many solutions")
+	}
+	if !(real(lo) < real(hi)) || !(imag(lo) < imag(hi)) {
+		return 0, errors.New("invalid rectangle")
+	}
+
+	// Counterclockwise around the corners, each side taking a quarter of t
+	corners := []complex128{lo, complex(real(hi), imag(lo)), hi, complex(real(lo), imag(hi)), lo}
+	path := func(t float64) complex128 {
+		side := math.Min(math.Floor(4.0*t), 3.0)
+		s := complex(4.0*t-side, 0)
+		i := int(side)
+		return corners[i] + s*(corners[i+1]-corners[i])
+	}
+
+	return windingNumber(poly.evalWithBound, path, poly.Degree())
+}
+
+// CountRootsInHalfPlane returns the number of roots in the open left half
+// plane Re z < 0, counted with multiplicity. A polynomial is Hurwitz stable
+// if all of its roots lie there.
+//
+// The half plane is mapped onto the unit disk with z = (w + 1) / (w - 1),
+// and the roots of q(w) = (w - 1)^n p((w + 1) / (w - 1)) are counted inside
+// the unit circle. Roots on the imaginary axis correspond to roots of q on
+// the circle and make the count fail.
+func (poly *Polynomial) CountRootsInHalfPlane() (int, error) {
+	if poly.IsZero() {
+		return 0, errors.New("infinitely many solutions")
+	}
+
+	eval := func(w complex128) (complex128, float64) {
+		u := w + 1
+		v := w - 1
+		au := cmplx.Abs(u)
+		av := cmplx.Abs(v)
+
+		// Horner's method for sum c_k u^(n-k) v^k
+		out := complex(poly.coeffs[0], 0)
+		bound := math.Abs(poly.coeffs[0])
+		vk := complex(1, 0)
+		avk := 1.0
+		for k := 1; k < len(poly.coeffs); k++ {
+			vk *= v
+			avk *= av
+			out = out*u + complex(poly.coeffs[k], 0)*vk
+			bound = bound*au + math.Abs(poly.coeffs[k])*avk
+		}
+		return out, bound
+	}
+
+	path := func(t float64) complex128 {
+		return cmplx.Exp(complex(0, 2.0*math.Pi*t))
+	}
+
+	return windingNumber(eval, path, poly.Degree())
+}
+
+// Horner's method returning the value and the sum of the absolute values
+// of the terms
+func (poly *Polynomial) evalWithBound(z complex128) (complex128, float64) {
+	out := complex(0, 0)
+	bound := 0.0
+	r := cmplx.Abs(z)
+	for _, c := range poly.coeffs {
+		out = out*z + complex(c, 0)
+		bound = bound*r + math.Abs(c)
+	}
+	return out, bound
+}
+
+// Winding number around the origin of f along the closed path z(t),
+// 0 <= t <= 1
+func windingNumber(f func(complex128) (complex128, float64), path func(float64) complex128, degree int) (int, error) {
+	eval := func(t float64) (complex128, error) {
+		w, bound := f(path(t))
+		if cmplx.Abs(w) <= EpsContour*bound || cmplx.IsNaN(w) {
+			return w, errors.New("root on the contour")
+		}
+		return w, nil
+	}
+
+	samples := 8 * (degree + 1)
+	total := 0.0
+
+	t0 := 0.0
+	w0, err := eval(t0)
+	if err != nil {
+		return 0, err
+	}
+	for i := 1; i <= samples; i++ {
+		t1 := float64(i) / float64(samples)
+		w1, err := eval(t1)
+		if err != nil {
+			return 0, err
+		}
+
+		change, err := phaseChange(eval, t0, t1, w0, w1, 0)
+		if err != nil {
+			return 0, err
+		}
+		total += change
+
+		t0, w0 = t1, w1
+	}
+
+	return int(math.Round(total / (2.0 * math.Pi))), nil
+}
+
+// Change in the phase of f between t0 and t1, splitting the segment until
+// the phase changes slowly on it
+func phaseChange(eval func(float64) (complex128, error), t0 float64, t1 float64, w0 complex128, w1 complex128, depth int) (float64, error) {
+	tm := t0 + (t1-t0)/2.0
+	wm, err := eval(tm)
+	if err != nil {
+		return 0, err
+	}
+
+	d1 := cmplx.Phase(wm / w0)
+	d2 := cmplx.Phase(w1 / wm)
+	if math.Abs(d1) <= maxPhaseStep && math.Abs(d2) <= maxPhaseStep {
+		return d1 + d2, nil
+	}
+
+	if depth >= MaxContourDepth {
+		return 0, errors.New("root on or too close to the contour")
+	}
+
+	d1, err = phaseChange(eval, t0, tm, w0, wm, depth+1)
+	if err != nil {
+		return 0, err
+	}
+	d2, err = phaseChange(eval, tm, t1, wm, w1, depth+1)
+	if err != nil {
+		return 0, err
+	}
+
+	return d1 + d2, nil
+}